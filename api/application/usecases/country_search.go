@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+// filterCountriesByName keeps the countries whose name matches term. Both
+// modes ignore case and accents; NameMatchFuzzy also tolerates typos.
+func filterCountriesByName(countries []valueobjects.CountrySummary, term string, match valueobjects.NameMatch) []valueobjects.CountrySummary {
+	term = normalizeName(term)
+
+	matched := []valueobjects.CountrySummary{}
+	for _, country := range countries {
+		name := normalizeName(country.Country.Name)
+		if strings.Contains(name, term) || (match == valueobjects.NameMatchFuzzy && fuzzyMatch(name, term)) {
+			matched = append(matched, country)
+		}
+	}
+	return matched
+}
+
+var removeAccents = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// normalizeName lower cases name, strips its accents and collapses
+// everything but letters and digits into single spaces.
+func normalizeName(name string) string {
+	if stripped, _, err := transform.String(removeAccents, name); err == nil {
+		name = stripped
+	}
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// fuzzyMatch reports whether every word of term is close to a word of name,
+// or to its beginning, allowing no edits in words of up to two letters, one in
+// words of up to five and two in longer ones.
+func fuzzyMatch(name, term string) bool {
+	nameWords := strings.Fields(name)
+	for _, termWord := range strings.Fields(term) {
+		if !fuzzyMatchWord(nameWords, termWord) {
+			return false
+		}
+	}
+	return true
+}
+
+func fuzzyMatchWord(nameWords []string, termWord string) bool {
+	term := []rune(termWord)
+	maxEdits := 0
+	switch {
+	case len(term) > 5:
+		maxEdits = 2
+	case len(term) > 2:
+		maxEdits = 1
+	}
+
+	for _, nameWord := range nameWords {
+		word := []rune(nameWord)
+		if editDistance(word, term) <= maxEdits {
+			return true
+		}
+		if len(word) > len(term) && editDistance(word[:len(term)], term) <= maxEdits {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
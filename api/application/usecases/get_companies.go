@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetCompaniesUseCase interface {
+	Execute(ctx context.Context) (*GetCompaniesOutput, error)
+}
+
+// GetCompaniesOutput lists every manufacturer with its adoption among all
+// MemberCountries.
+type GetCompaniesOutput struct {
+	MemberCountries int
+	Companies       []CompanyOutput
+}
+
+// CompanyOutput is a manufacturer with the vaccines it produces, how many of
+// them the member countries use and how many of those countries use at least
+// one. Share is the fraction of member countries that do; a country using
+// several manufacturers counts for each, so shares do not add up to 1.
+type CompanyOutput struct {
+	Name         string
+	Products     int
+	ProductsUsed int
+	Countries    int
+	Share        float64
+}
+
+type getCompaniesUseCase struct {
+	companyRepo repositories.CompanyRepository
+}
+
+func NewGetCompaniesUseCase(repo repositories.CompanyRepository) GetCompaniesUseCase {
+	return &getCompaniesUseCase{
+		companyRepo: repo,
+	}
+}
+
+func (uc *getCompaniesUseCase) Execute(ctx context.Context) (*GetCompaniesOutput, error) {
+	adoption, err := uc.companyRepo.ListCompanies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCompaniesOutput{
+		MemberCountries: adoption.MemberCountries,
+		Companies:       companyOutputs(adoption),
+	}, nil
+}
+
+// companyOutputs converts the usage of each company, in the same order.
+func companyOutputs(adoption *valueobjects.CompanyAdoption) []CompanyOutput {
+	companies := make([]CompanyOutput, len(adoption.Companies))
+	for i, usage := range adoption.Companies {
+		companies[i] = CompanyOutput{
+			Name:         usage.Company.Name,
+			Products:     usage.Products,
+			ProductsUsed: usage.ProductsUsed,
+			Countries:    usage.Countries,
+			Share:        fraction(float64(usage.Countries), float64(adoption.MemberCountries)),
+		}
+	}
+	return companies
+}
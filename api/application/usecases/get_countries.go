@@ -0,0 +1,165 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetCountriesUseCase interface {
+	Execute(ctx context.Context, input GetCountriesInput) (*GetCountriesOutput, error)
+}
+
+// GetCountriesInput selects a page of the country catalogue. An empty Name
+// keeps every country, and a nil Cursor starts at the first page.
+type GetCountriesInput struct {
+	Region             string
+	Name               string
+	Match              valueobjects.NameMatch
+	HasVaccinationData *bool
+	Sort               valueobjects.CountrySort
+	Order              valueobjects.SortOrder
+	Cursor             *valueobjects.Cursor
+	Limit              int
+}
+
+type GetCountriesOutput struct {
+	Countries  []CountryCatalogueEntry
+	NextCursor string `json:",omitempty"`
+}
+
+type CountryCatalogueEntry struct {
+	Country             entities.Country
+	Region              string
+	CovidCoverage       *DateCoverage
+	VaccinationCoverage *DateCoverage
+}
+
+// DateCoverage is the first and last date a country has reports for.
+type DateCoverage struct {
+	From string
+	To   string
+}
+
+type getCountriesUseCase struct {
+	countryRepo repositories.CountryRepository
+}
+
+func NewGetCountriesUseCase(repo repositories.CountryRepository) GetCountriesUseCase {
+	return &getCountriesUseCase{
+		countryRepo: repo,
+	}
+}
+
+func (uc *getCountriesUseCase) Execute(ctx context.Context, input GetCountriesInput) (*GetCountriesOutput, error) {
+	countries, err := uc.countryRepo.ListCountries(ctx, valueobjects.CountryFilter{
+		Region:             input.Region,
+		HasVaccinationData: input.HasVaccinationData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		countries = filterCountriesByName(countries, input.Name, input.Match)
+	}
+
+	page, next := paginateCountries(countries, input.Sort, input.Order, input.Cursor, input.Limit)
+
+	output := &GetCountriesOutput{
+		Countries: make([]CountryCatalogueEntry, len(page)),
+	}
+	for i, country := range page {
+		output.Countries[i] = CountryCatalogueEntry{
+			Country:             country.Country,
+			Region:              country.Region,
+			CovidCoverage:       newDateCoverage(country.Covid),
+			VaccinationCoverage: newDateCoverage(country.Vaccination),
+		}
+	}
+	if next != nil {
+		output.NextCursor = next.Encode()
+	}
+
+	return output, nil
+}
+
+func newDateCoverage(coverage valueobjects.DateCoverage) *DateCoverage {
+	if coverage.From.IsZero() || coverage.To.IsZero() {
+		return nil
+	}
+	return &DateCoverage{
+		From: coverage.From.Format("2006-01-02"),
+		To:   coverage.To.Format("2006-01-02"),
+	}
+}
+
+// countrySortValue is the value a country is sorted by, compared case
+// insensitively.
+func countrySortValue(country valueobjects.CountrySummary, field valueobjects.CountrySort) string {
+	switch field {
+	case valueobjects.CountrySortCode:
+		return country.Country.Code
+	case valueobjects.CountrySortRegion:
+		return strings.ToLower(country.Region)
+	default:
+		return strings.ToLower(country.Country.Name)
+	}
+}
+
+// paginateCountries sorts countries by field, breaking ties by code, and
+// returns up to limit of them after cursor along with the cursor of the next
+// page, which is nil on the last page.
+func paginateCountries(countries []valueobjects.CountrySummary, field valueobjects.CountrySort, order valueobjects.SortOrder, cursor *valueobjects.Cursor, limit int) ([]valueobjects.CountrySummary, *valueobjects.Cursor) {
+	keys := make([]valueobjects.Cursor, len(countries))
+	for i, country := range countries {
+		keys[i] = valueobjects.Cursor{Value: countrySortValue(country, field), Code: country.Country.Code}
+	}
+
+	// before reports whether a sorts before b in the requested order
+	before := func(a, b valueobjects.Cursor) bool {
+		if order == valueobjects.SortDescending {
+			a, b = b, a
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.Code < b.Code
+	}
+
+	indexes := make([]int, len(countries))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return before(keys[indexes[i]], keys[indexes[j]])
+	})
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(indexes), func(i int) bool {
+			return before(*cursor, keys[indexes[i]])
+		})
+	}
+
+	end := start + limit
+	if end > len(indexes) {
+		end = len(indexes)
+	}
+
+	page := make([]valueobjects.CountrySummary, 0, end-start)
+	for _, i := range indexes[start:end] {
+		page = append(page, countries[i])
+	}
+
+	var next *valueobjects.Cursor
+	if end < len(indexes) && end > start {
+		next = &keys[indexes[end-1]]
+	}
+
+	return page, next
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func summary(code, name, region string) valueobjects.CountrySummary {
+	return valueobjects.CountrySummary{
+		Country: entities.Country{Code: code, Name: name},
+		Region:  region,
+	}
+}
+
+func codes(countries []valueobjects.CountrySummary) []string {
+	result := make([]string, len(countries))
+	for i, country := range countries {
+		result[i] = country.Country.Code
+	}
+	return result
+}
+
+func equalCodes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFilterCountriesByName(t *testing.T) {
+	countries := []valueobjects.CountrySummary{
+		summary("BRA", "Brazil", "AMRO"),
+		summary("CIV", "Côte d’Ivoire", "AFRO"),
+		summary("USA", "United States of America", "AMRO"),
+		summary("GBR", "United Kingdom of Great Britain and Northern Ireland", "EURO"),
+		summary("DEU", "Germany", "EURO"),
+	}
+
+	tests := []struct {
+		term  string
+		match valueobjects.NameMatch
+		want  []string
+	}{
+		{"united", valueobjects.NameMatchContains, []string{"USA", "GBR"}},
+		{"cote d ivoire", valueobjects.NameMatchContains, []string{"CIV"}},
+		{"brazl", valueobjects.NameMatchContains, []string{}},
+		{"brazl", valueobjects.NameMatchFuzzy, []string{"BRA"}},
+		{"untied states", valueobjects.NameMatchFuzzy, []string{"USA"}},
+		{"germ", valueobjects.NameMatchFuzzy, []string{"DEU"}},
+		{"cote", valueobjects.NameMatchFuzzy, []string{"CIV"}},
+		{"of", valueobjects.NameMatchFuzzy, []string{"USA", "GBR"}},
+		{"atlantis", valueobjects.NameMatchFuzzy, []string{}},
+	}
+
+	for _, tt := range tests {
+		got := codes(filterCountriesByName(countries, tt.term, tt.match))
+		if !equalCodes(got, tt.want) {
+			t.Errorf("%s %q: got %v, want %v", tt.match, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestPaginateCountries(t *testing.T) {
+	countries := []valueobjects.CountrySummary{
+		summary("DEU", "Germany", "EURO"),
+		summary("BRA", "Brazil", "AMRO"),
+		summary("USA", "United States of America", "AMRO"),
+		summary("FRA", "France", "EURO"),
+		summary("ARG", "Argentina", "AMRO"),
+	}
+
+	tests := []struct {
+		sort  valueobjects.CountrySort
+		order valueobjects.SortOrder
+		want  []string
+	}{
+		{valueobjects.CountrySortName, valueobjects.SortAscending, []string{"ARG", "BRA", "FRA", "DEU", "USA"}},
+		{valueobjects.CountrySortCode, valueobjects.SortDescending, []string{"USA", "FRA", "DEU", "BRA", "ARG"}},
+		{valueobjects.CountrySortRegion, valueobjects.SortAscending, []string{"ARG", "BRA", "USA", "DEU", "FRA"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		var cursor *valueobjects.Cursor
+		for pages := 0; pages < 5; pages++ {
+			page, next := paginateCountries(countries, tt.sort, tt.order, cursor, 2)
+			got = append(got, codes(page)...)
+
+			if next == nil {
+				break
+			}
+			// Cursors travel through clients as tokens
+			cursor, _ = valueobjects.ParseCursor(next.Encode())
+		}
+
+		if !equalCodes(got, tt.want) {
+			t.Errorf("%s %s: got %v, want %v", tt.sort, tt.order, got, tt.want)
+		}
+	}
+}
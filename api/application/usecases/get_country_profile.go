@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetCountryProfileUseCase interface {
+	Execute(ctx context.Context, input GetCountryProfileInput) (*GetCountryProfileOutput, error)
+}
+
+type GetCountryProfileInput struct {
+	CountryCode string
+	AsOf        time.Time
+}
+
+// GetCountryProfileOutput is everything known about a country as of a date.
+// Sections the country has no data for are null, and Freshness tells which
+// reports the others come from.
+type GetCountryProfileOutput struct {
+	Country     entities.Country
+	Region      *string
+	AsOf        string
+	Covid       *CountryProfileCovid
+	Vaccination *CountryProfileVaccination
+	Vaccines    []GetVaccinesUsedOutput
+	Freshness   CountryProfileFreshness
+}
+
+type CountryProfileCovid struct {
+	CumulativeCases  *int
+	NewCases         *int
+	CumulativeDeaths *int
+	NewDeaths        *int
+}
+
+type CountryProfileVaccination struct {
+	TotalVaccinations                *int
+	TotalVaccinationsPer100          *float64
+	PersonsVaccinated1PlusDose       *int
+	PersonsVaccinated1PlusDosePer100 *float64
+	PersonsFullyVaccinated           *int
+	PersonsFullyVaccinatedPer100     *float64
+	PersonsBoosterAddDose            *int
+	PersonsBoosterAddDosePer100      *float64
+}
+
+type CountryProfileFreshness struct {
+	Covid       *Observation
+	Vaccination *Observation
+}
+
+type getCountryProfileUseCase struct {
+	countryRepo          repositories.CountryRepository
+	regionRepo           repositories.RegionRepository
+	covidStatsRepo       repositories.CovidStatsRepository
+	vaccinationStatsRepo repositories.VaccinationStatsRepository
+	vaccineRepo          repositories.VaccineRepository
+	timeout              time.Duration
+}
+
+// NewGetCountryProfileUseCase returns a use case that reads the parts of a
+// profile concurrently, giving up on all of them once timeout has passed.
+func NewGetCountryProfileUseCase(
+	countryRepo repositories.CountryRepository,
+	regionRepo repositories.RegionRepository,
+	covidStatsRepo repositories.CovidStatsRepository,
+	vaccinationStatsRepo repositories.VaccinationStatsRepository,
+	vaccineRepo repositories.VaccineRepository,
+	timeout time.Duration,
+) GetCountryProfileUseCase {
+	return &getCountryProfileUseCase{
+		countryRepo:          countryRepo,
+		regionRepo:           regionRepo,
+		covidStatsRepo:       covidStatsRepo,
+		vaccinationStatsRepo: vaccinationStatsRepo,
+		vaccineRepo:          vaccineRepo,
+		timeout:              timeout,
+	}
+}
+
+func (uc *getCountryProfileUseCase) Execute(ctx context.Context, input GetCountryProfileInput) (*GetCountryProfileOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	var (
+		wg sync.WaitGroup
+
+		country     *entities.Country
+		region      *entities.Region
+		covid       *valueobjects.CovidStats
+		vaccination *valueobjects.VaccinationStats
+		vaccines    []struct {
+			Vaccine           entities.Vaccine
+			StartDate         *time.Time
+			AuthorizationDate *time.Time
+			EndDate           *time.Time
+		}
+
+		countryErr, regionErr, covidErr, vaccinationErr, vaccinesErr error
+	)
+
+	wg.Add(5)
+	go func() {
+		defer wg.Done()
+		country, countryErr = uc.countryRepo.GetCountryByCode(ctx, input.CountryCode)
+	}()
+	go func() {
+		defer wg.Done()
+		region, regionErr = uc.regionRepo.GetCountryRegion(ctx, input.CountryCode)
+	}()
+	go func() {
+		defer wg.Done()
+		covid, covidErr = uc.covidStatsRepo.GetTotalCasesAndDeaths(ctx, input.CountryCode, input.AsOf, valueobjects.DateModeAsOf)
+	}()
+	go func() {
+		defer wg.Done()
+		vaccination, vaccinationErr = uc.vaccinationStatsRepo.GetVaccinatedPeople(ctx, input.CountryCode, input.AsOf, valueobjects.DateModeAsOf)
+	}()
+	go func() {
+		defer wg.Done()
+		vaccines, vaccinesErr = uc.vaccineRepo.GetVaccinesUsed(ctx, input.CountryCode)
+	}()
+	wg.Wait()
+
+	// Only the country itself is required; missing data leaves its section empty
+	if countryErr != nil {
+		return nil, countryErr
+	}
+	for _, part := range []struct {
+		name string
+		err  error
+	}{
+		{"region", regionErr},
+		{"COVID-19", covidErr},
+		{"vaccination", vaccinationErr},
+		{"vaccines", vaccinesErr},
+	} {
+		if part.err != nil && !errors.Is(part.err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("failed to read %s data of country %s: %w", part.name, input.CountryCode, part.err)
+		}
+	}
+
+	output := &GetCountryProfileOutput{
+		Country:  *country,
+		AsOf:     input.AsOf.Format("2006-01-02"),
+		Vaccines: vaccinesUsedOutput(vaccines),
+	}
+	if region != nil {
+		output.Region = &region.Name
+	}
+	if covid != nil {
+		output.Covid = &CountryProfileCovid{
+			CumulativeCases:  covid.CumulativeCases,
+			NewCases:         covid.NewCases,
+			CumulativeDeaths: covid.CumulativeDeaths,
+			NewDeaths:        covid.NewDeaths,
+		}
+		observation := newObservation(input.AsOf, covid.ReportedOn)
+		output.Freshness.Covid = &observation
+	}
+	if vaccination != nil {
+		output.Vaccination = &CountryProfileVaccination{
+			TotalVaccinations:                vaccination.TotalVaccinations,
+			TotalVaccinationsPer100:          vaccination.TotalVaccinationsPer100,
+			PersonsVaccinated1PlusDose:       vaccination.PersonsVaccinated1PlusDose,
+			PersonsVaccinated1PlusDosePer100: vaccination.PersonsVaccinated1PlusDosePer100,
+			PersonsFullyVaccinated:           vaccination.PersonsLastDose,
+			PersonsFullyVaccinatedPer100:     vaccination.PersonsLastDosePer100,
+			PersonsBoosterAddDose:            vaccination.PersonsBoosterAddDose,
+			PersonsBoosterAddDosePer100:      vaccination.PersonsBoosterAddDosePer100,
+		}
+		observation := newObservation(input.AsOf, vaccination.ReportedOn)
+		output.Freshness.Vaccination = &observation
+	}
+	if output.Vaccines == nil {
+		output.Vaccines = []GetVaccinesUsedOutput{}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type stubCountryRepo struct {
+	repositories.CountryRepository
+}
+
+func (stubCountryRepo) GetCountryByCode(ctx context.Context, code string) (*entities.Country, error) {
+	return &entities.Country{Code: code, Name: "Brazil"}, nil
+}
+
+type stubRegionRepo struct {
+	repositories.RegionRepository
+}
+
+func (stubRegionRepo) GetCountryRegion(ctx context.Context, countryCode string) (*entities.Region, error) {
+	return &entities.Region{Name: "AMRO"}, nil
+}
+
+type stubCovidStatsRepo struct {
+	repositories.CovidStatsRepository
+	delay time.Duration
+}
+
+func (r stubCovidStatsRepo) GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.CovidStats, error) {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	stats := valueobjects.NewCovidStats(nil, nil, intPtr(100), nil)
+	stats.ReportedOn = date.AddDate(0, 0, -3)
+	return &stats, nil
+}
+
+type stubVaccinationStatsRepo struct {
+	repositories.VaccinationStatsRepository
+	err error
+}
+
+func (r stubVaccinationStatsRepo) GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.VaccinationStats, error) {
+	return nil, r.err
+}
+
+type stubVaccineRepo struct {
+	repositories.VaccineRepository
+}
+
+func (stubVaccineRepo) GetVaccinesUsed(ctx context.Context, countryCode string) ([]struct {
+	Vaccine           entities.Vaccine
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
+}, error) {
+	return nil, nil
+}
+
+func TestGetCountryProfile(t *testing.T) {
+	asOf, _ := time.Parse("2006-01-02", "2023-06-01")
+	input := GetCountryProfileInput{CountryCode: "BRA", AsOf: asOf}
+
+	t.Run("missing sections are empty", func(t *testing.T) {
+		uc := NewGetCountryProfileUseCase(stubCountryRepo{}, stubRegionRepo{}, stubCovidStatsRepo{},
+			stubVaccinationStatsRepo{err: domain.NotFoundf("no vaccination statistics")}, stubVaccineRepo{}, time.Second)
+
+		output, err := uc.Execute(context.Background(), input)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Region == nil || *output.Region != "AMRO" {
+			t.Errorf("got region %v, want AMRO", output.Region)
+		}
+		if output.Covid == nil || *output.Covid.CumulativeCases != 100 {
+			t.Errorf("got COVID-19 section %+v, want 100 cumulative cases", output.Covid)
+		}
+		if output.Freshness.Covid == nil || output.Freshness.Covid.StalenessDays != 3 {
+			t.Errorf("got COVID-19 freshness %+v, want 3 days stale", output.Freshness.Covid)
+		}
+		if output.Vaccination != nil || output.Freshness.Vaccination != nil {
+			t.Errorf("got vaccination section %+v, want none", output.Vaccination)
+		}
+		if output.Vaccines == nil {
+			t.Error("got null vaccines, want an empty list")
+		}
+	})
+
+	t.Run("failures are returned", func(t *testing.T) {
+		failure := errors.New("connection refused")
+		uc := NewGetCountryProfileUseCase(stubCountryRepo{}, stubRegionRepo{}, stubCovidStatsRepo{},
+			stubVaccinationStatsRepo{err: failure}, stubVaccineRepo{}, time.Second)
+
+		if _, err := uc.Execute(context.Background(), input); !errors.Is(err, failure) {
+			t.Errorf("got error %v, want %v", err, failure)
+		}
+	})
+
+	t.Run("slow reads time out", func(t *testing.T) {
+		uc := NewGetCountryProfileUseCase(stubCountryRepo{}, stubRegionRepo{}, stubCovidStatsRepo{delay: time.Minute},
+			stubVaccinationStatsRepo{}, stubVaccineRepo{}, 10*time.Millisecond)
+
+		if _, err := uc.Execute(context.Background(), input); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
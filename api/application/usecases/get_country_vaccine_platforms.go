@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+// unknownPlatform groups the vaccines the platform mapping does not cover.
+const unknownPlatform = "unknown"
+
+type GetCountryVaccinePlatformsUseCase interface {
+	Execute(ctx context.Context, input GetCountryVaccinePlatformsInput) (*GetCountryVaccinePlatformsOutput, error)
+}
+
+type GetCountryVaccinePlatformsInput struct {
+	CountryCode string
+}
+
+// GetCountryVaccinePlatformsOutput splits the vaccines a country uses by
+// platform, most used platform first.
+type GetCountryVaccinePlatformsOutput struct {
+	CountryCode string
+	Vaccines    int
+	Platforms   []CountryVaccinePlatform
+}
+
+// CountryVaccinePlatform is a platform with the country's vaccines that use
+// it, ordered by product, and their share of all its vaccines.
+type CountryVaccinePlatform struct {
+	Platform string
+	Vaccines int
+	Share    float64
+	Products []string
+}
+
+type getCountryVaccinePlatformsUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetCountryVaccinePlatformsUseCase(repo repositories.VaccineRepository) GetCountryVaccinePlatformsUseCase {
+	return &getCountryVaccinePlatformsUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getCountryVaccinePlatformsUseCase) Execute(ctx context.Context, input GetCountryVaccinePlatformsInput) (*GetCountryVaccinePlatformsOutput, error) {
+	vaccinesUsed, err := uc.vaccineRepo.GetVaccinesUsed(ctx, input.CountryCode)
+	if err != nil {
+		return nil, err
+	}
+
+	byPlatform := make(map[string]*CountryVaccinePlatform)
+	vaccines := vaccinesUsedOutput(vaccinesUsed)
+	for _, used := range vaccines {
+		platform := platformOf(used.Vaccine)
+		group, ok := byPlatform[platform]
+		if !ok {
+			group = &CountryVaccinePlatform{Platform: platform}
+			byPlatform[platform] = group
+		}
+		group.Vaccines++
+		group.Products = append(group.Products, used.Vaccine.Product)
+	}
+
+	output := &GetCountryVaccinePlatformsOutput{
+		CountryCode: input.CountryCode,
+		Vaccines:    len(vaccines),
+		Platforms:   make([]CountryVaccinePlatform, 0, len(byPlatform)),
+	}
+	for _, group := range byPlatform {
+		group.Share = fraction(float64(group.Vaccines), float64(len(vaccines)))
+		sort.Strings(group.Products)
+		output.Platforms = append(output.Platforms, *group)
+	}
+	sort.Slice(output.Platforms, func(i, j int) bool {
+		a, b := output.Platforms[i], output.Platforms[j]
+		if a.Vaccines != b.Vaccines {
+			return a.Vaccines > b.Vaccines
+		}
+		return a.Platform < b.Platform
+	})
+
+	return output, nil
+}
+
+// platformOf returns the platform of vaccine, or unknownPlatform when the
+// mapping does not cover it.
+func platformOf(vaccine entities.Vaccine) string {
+	if vaccine.Platform == "" {
+		return unknownPlatform
+	}
+	return vaccine.Platform
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+type stubCountryVaccinesRepo struct {
+	stubVaccineRepo
+	vaccines []entities.Vaccine
+}
+
+func (r stubCountryVaccinesRepo) GetVaccinesUsed(ctx context.Context, countryCode string) ([]struct {
+	Vaccine           entities.Vaccine
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
+}, error) {
+	used := make([]struct {
+		Vaccine           entities.Vaccine
+		StartDate         *time.Time
+		AuthorizationDate *time.Time
+		EndDate           *time.Time
+	}, len(r.vaccines))
+	for i, vaccine := range r.vaccines {
+		used[i].Vaccine = vaccine
+	}
+	return used, nil
+}
+
+func TestGetCountryVaccinePlatforms(t *testing.T) {
+	pfizer := entities.Vaccine{Product: "Pfizer BioNTech - Comirnaty", Vaccine: "Comirnaty", Platform: "mrna"}
+	moderna := entities.Vaccine{Product: "Moderna - Spikevax", Vaccine: "Spikevax", Platform: "mrna"}
+	sinovac := entities.Vaccine{Product: "SinoVac - CoronaVac", Vaccine: "CoronaVac", Platform: "inactivated"}
+	unmapped := entities.Vaccine{Product: "Unmapped - Vaccine", Vaccine: "Unmapped"}
+
+	uc := NewGetCountryVaccinePlatformsUseCase(stubCountryVaccinesRepo{
+		vaccines: []entities.Vaccine{sinovac, pfizer, unmapped, moderna, pfizer},
+	})
+
+	output, err := uc.Execute(context.Background(), GetCountryVaccinePlatformsInput{CountryCode: "BRA"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []CountryVaccinePlatform{
+		{Platform: "mrna", Vaccines: 2, Share: 0.5, Products: []string{"Moderna - Spikevax", "Pfizer BioNTech - Comirnaty"}},
+		{Platform: "inactivated", Vaccines: 1, Share: 0.25, Products: []string{"SinoVac - CoronaVac"}},
+		{Platform: unknownPlatform, Vaccines: 1, Share: 0.25, Products: []string{"Unmapped - Vaccine"}},
+	}
+	if output.CountryCode != "BRA" || output.Vaccines != 4 {
+		t.Errorf("got %s with %d vaccines, want BRA with 4", output.CountryCode, output.Vaccines)
+	}
+	if !reflect.DeepEqual(output.Platforms, want) {
+		t.Errorf("got platforms %+v, want %+v", output.Platforms, want)
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/entities"
-	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type GetCountryWithMostCasesUseCase interface {
@@ -14,39 +15,47 @@ type GetCountryWithMostCasesUseCase interface {
 
 type GetCountryWithMostCasesInput struct {
 	Date time.Time
+	Mode valueobjects.DateMode
 }
 
 type GetCountryWithMostCasesOutput struct {
+	Observation
 	Country         entities.Country
 	CumulativeCases int
 }
 
+// getCountryWithMostCasesUseCase is the top entry of the cumulative cases
+// ranking.
 type getCountryWithMostCasesUseCase struct {
-	covidStatsRepo repositories.CovidStatsRepository
-	countryRepo    repositories.CountryRepository
+	getRankingUC GetRankingUseCase
 }
 
-func NewGetCountryWithMostCasesUseCase(covidRepo repositories.CovidStatsRepository, countryRepo repositories.CountryRepository) GetCountryWithMostCasesUseCase {
+func NewGetCountryWithMostCasesUseCase(getRankingUC GetRankingUseCase) GetCountryWithMostCasesUseCase {
 	return &getCountryWithMostCasesUseCase{
-		covidStatsRepo: covidRepo,
-		countryRepo:    countryRepo,
+		getRankingUC: getRankingUC,
 	}
 }
 
 func (uc *getCountryWithMostCasesUseCase) Execute(ctx context.Context, input GetCountryWithMostCasesInput) (*GetCountryWithMostCasesOutput, error) {
-
-	countryWithMostCases, cumulativeCases, err := uc.covidStatsRepo.GetCountryWithMostCases(ctx, input.Date)
+	ranking, err := uc.getRankingUC.Execute(ctx, GetRankingInput{
+		Metric: valueobjects.MetricCumulativeCases,
+		Date:   input.Date,
+		Mode:   input.Mode,
+		Order:  valueobjects.SortDescending,
+		Limit:  1,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	country, err := uc.countryRepo.GetCountryByCode(ctx, countryWithMostCases)
-	if err != nil {
-		return nil, err
+	if len(ranking.Entries) == 0 {
+		return nil, domain.NotFoundf("no COVID-19 statistics found for date %s", input.Date.Format("2006-01-02"))
 	}
+	top := ranking.Entries[0]
 
 	return &GetCountryWithMostCasesOutput{
-		Country:         *country,
-		CumulativeCases: cumulativeCases,
+		Observation:     top.Observation,
+		Country:         top.Country,
+		CumulativeCases: int(top.Value),
 	}, nil
 }
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetCovidSeriesUseCase interface {
+	Execute(ctx context.Context, input GetCovidSeriesInput) (*GetCovidSeriesOutput, error)
+}
+
+// GetCovidSeriesInput selects the reports of a country between From and To,
+// inclusive. A zero From or To leaves that end open.
+type GetCovidSeriesInput struct {
+	CountryCode string
+	From        time.Time
+	To          time.Time
+	Interval    valueobjects.Interval
+}
+
+type GetCovidSeriesOutput struct {
+	CountryCode string
+	Interval    valueobjects.Interval
+	Points      []CovidSeriesPoint
+}
+
+// CovidSeriesPoint covers one period of the series, starting on Date. New
+// cases and deaths are summed over the period; the cumulative counts are the
+// last ones reported in it. A count no report of the period had is nil.
+type CovidSeriesPoint struct {
+	Date             string
+	NewCases         *int
+	CumulativeCases  *int
+	NewDeaths        *int
+	CumulativeDeaths *int
+}
+
+type getCovidSeriesUseCase struct {
+	covidStatsRepo repositories.CovidStatsRepository
+}
+
+func NewGetCovidSeriesUseCase(repo repositories.CovidStatsRepository) GetCovidSeriesUseCase {
+	return &getCovidSeriesUseCase{
+		covidStatsRepo: repo,
+	}
+}
+
+func (uc *getCovidSeriesUseCase) Execute(ctx context.Context, input GetCovidSeriesInput) (*GetCovidSeriesOutput, error) {
+	if !input.From.IsZero() && !input.To.IsZero() && input.From.After(input.To) {
+		return nil, domain.InvalidInputf("from %s is after to %s", input.From.Format("2006-01-02"), input.To.Format("2006-01-02"))
+	}
+
+	series, err := uc.covidStatsRepo.GetCovidSeries(ctx, input.CountryCode, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCovidSeriesOutput{
+		CountryCode: input.CountryCode,
+		Interval:    input.Interval,
+		Points:      resampleCovidSeries(series, input.Interval),
+	}, nil
+}
+
+// resampleCovidSeries groups date-ordered reports into the periods of
+// interval, one point per period that has reports.
+func resampleCovidSeries(series []valueobjects.CovidStats, interval valueobjects.Interval) []CovidSeriesPoint {
+	points := []CovidSeriesPoint{}
+
+	var current *CovidSeriesPoint
+	var currentStart time.Time
+	for _, stats := range series {
+		start := interval.PeriodStart(stats.ReportedOn)
+		if current == nil || !start.Equal(currentStart) {
+			points = append(points, CovidSeriesPoint{Date: start.Format("2006-01-02")})
+			current = &points[len(points)-1]
+			currentStart = start
+		}
+
+		current.NewCases = addCount(current.NewCases, stats.NewCases)
+		current.NewDeaths = addCount(current.NewDeaths, stats.NewDeaths)
+		if stats.CumulativeCases != nil {
+			current.CumulativeCases = stats.CumulativeCases
+		}
+		if stats.CumulativeDeaths != nil {
+			current.CumulativeDeaths = stats.CumulativeDeaths
+		}
+	}
+
+	return points
+}
+
+// addCount adds n to total, where a nil count was not reported. The sum is
+// only nil when neither was.
+func addCount(total, n *int) *int {
+	if n == nil {
+		return total
+	}
+	sum := *n
+	if total != nil {
+		sum += *total
+	}
+	return &sum
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func intPtr(n int) *int { return &n }
+
+func report(date string, newCases, cumulativeCases *int) valueobjects.CovidStats {
+	reportedOn, _ := time.Parse("2006-01-02", date)
+	return valueobjects.CovidStats{
+		ReportedOn:      reportedOn,
+		NewCases:        newCases,
+		CumulativeCases: cumulativeCases,
+	}
+}
+
+func TestResampleCovidSeries(t *testing.T) {
+	series := []valueobjects.CovidStats{
+		report("2023-01-01", intPtr(10), intPtr(100)),
+		report("2023-01-08", nil, nil),
+		report("2023-01-15", intPtr(5), intPtr(105)),
+		report("2023-02-05", intPtr(7), intPtr(112)),
+	}
+
+	tests := []struct {
+		interval      valueobjects.Interval
+		wantDates     []string
+		wantNew       []*int
+		wantCumulated []*int
+	}{
+		{
+			interval:      valueobjects.IntervalDay,
+			wantDates:     []string{"2023-01-01", "2023-01-08", "2023-01-15", "2023-02-05"},
+			wantNew:       []*int{intPtr(10), nil, intPtr(5), intPtr(7)},
+			wantCumulated: []*int{intPtr(100), nil, intPtr(105), intPtr(112)},
+		},
+		{
+			// Sundays fall in the week that started on the Monday before
+			interval:      valueobjects.IntervalWeek,
+			wantDates:     []string{"2022-12-26", "2023-01-02", "2023-01-09", "2023-01-30"},
+			wantNew:       []*int{intPtr(10), nil, intPtr(5), intPtr(7)},
+			wantCumulated: []*int{intPtr(100), nil, intPtr(105), intPtr(112)},
+		},
+		{
+			interval:      valueobjects.IntervalMonth,
+			wantDates:     []string{"2023-01-01", "2023-02-01"},
+			wantNew:       []*int{intPtr(15), intPtr(7)},
+			wantCumulated: []*int{intPtr(105), intPtr(112)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.interval), func(t *testing.T) {
+			points := resampleCovidSeries(series, tt.interval)
+			if len(points) != len(tt.wantDates) {
+				t.Fatalf("got %d points; want %d", len(points), len(tt.wantDates))
+			}
+
+			for i, point := range points {
+				if point.Date != tt.wantDates[i] {
+					t.Errorf("points[%d].Date = %s; want %s", i, point.Date, tt.wantDates[i])
+				}
+				if !equalCount(point.NewCases, tt.wantNew[i]) {
+					t.Errorf("points[%d].NewCases = %v; want %v", i, point.NewCases, tt.wantNew[i])
+				}
+				if !equalCount(point.CumulativeCases, tt.wantCumulated[i]) {
+					t.Errorf("points[%d].CumulativeCases = %v; want %v", i, point.CumulativeCases, tt.wantCumulated[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResampleCovidSeriesEmpty(t *testing.T) {
+	points := resampleCovidSeries(nil, valueobjects.IntervalWeek)
+	if points == nil || len(points) != 0 {
+		t.Errorf("resampleCovidSeries(nil) = %v; want an empty series", points)
+	}
+}
+
+func TestGetCovidSeriesRejectsReversedRange(t *testing.T) {
+	input := GetCovidSeriesInput{
+		CountryCode: "BRA",
+		From:        time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// The range is checked before the repository is read
+	_, err := NewGetCovidSeriesUseCase(nil).Execute(context.Background(), input)
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Execute() error = %v; want ErrInvalidInput", err)
+	}
+}
+
+func equalCount(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
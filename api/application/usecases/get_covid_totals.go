@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type GetCovidTotalsUseCase interface {
@@ -14,13 +15,15 @@ type GetCovidTotalsUseCase interface {
 type GetCovidTotalsInput struct {
 	CountryCode string
 	Date        time.Time
+	Mode        valueobjects.DateMode
 }
 
 type GetCovidTotalsOutput struct {
-	CumulativeCases  int
-	NewCases         int
-	CumulativeDeaths int
-	NewDeaths        int
+	Observation
+	CumulativeCases  *int
+	NewCases         *int
+	CumulativeDeaths *int
+	NewDeaths        *int
 }
 
 type getCovidTotalsUseCase struct {
@@ -34,12 +37,13 @@ func NewGetCovidTotalsUseCase(repo repositories.CovidStatsRepository) GetCovidTo
 }
 
 func (uc *getCovidTotalsUseCase) Execute(ctx context.Context, input GetCovidTotalsInput) (*GetCovidTotalsOutput, error) {
-	stats, err := uc.covidStatsRepo.GetTotalCasesAndDeaths(ctx, input.CountryCode, input.Date)
+	stats, err := uc.covidStatsRepo.GetTotalCasesAndDeaths(ctx, input.CountryCode, input.Date, input.Mode)
 	if err != nil {
 		return nil, err
 	}
 
 	return &GetCovidTotalsOutput{
+		Observation:      newObservation(input.Date, stats.ReportedOn),
 		CumulativeCases:  stats.CumulativeCases,
 		NewCases:         stats.NewCases,
 		CumulativeDeaths: stats.CumulativeDeaths,
@@ -3,8 +3,9 @@ package usecases
 import (
 	"context"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/entities"
-	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type GetMostUsedVaccineUseCase interface {
@@ -15,29 +16,42 @@ type GetMostUsedVaccineInput struct {
 	RegionName string
 }
 
+// GetMostUsedVaccineOutput is the vaccine adopted by the most countries of
+// the region, with how many adopted it.
 type GetMostUsedVaccineOutput struct {
 	Vaccine entities.Vaccine
 	Usage   int
 }
 
+// getMostUsedVaccineUseCase is the top entry of the region's vaccine ranking
+// by adopting countries.
 type getMostUsedVaccineUseCase struct {
-	vaccineRepo repositories.VaccineRepository
+	getRegionVaccineRankingUC GetRegionVaccineRankingUseCase
 }
 
-func NewGetMostUsedVaccineUseCase(repo repositories.VaccineRepository) GetMostUsedVaccineUseCase {
+func NewGetMostUsedVaccineUseCase(getRegionVaccineRankingUC GetRegionVaccineRankingUseCase) GetMostUsedVaccineUseCase {
 	return &getMostUsedVaccineUseCase{
-		vaccineRepo: repo,
+		getRegionVaccineRankingUC: getRegionVaccineRankingUC,
 	}
 }
 
 func (uc *getMostUsedVaccineUseCase) Execute(ctx context.Context, input GetMostUsedVaccineInput) (*GetMostUsedVaccineOutput, error) {
-	mostUsedVaccine, usage, err := uc.vaccineRepo.GetMostUsedVaccine(ctx, input.RegionName)
+	ranking, err := uc.getRegionVaccineRankingUC.Execute(ctx, GetRegionVaccineRankingInput{
+		RegionName: input.RegionName,
+		Weight:     valueobjects.WeightCountries,
+		Limit:      1,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(ranking.Vaccines) == 0 {
+		return nil, domain.NotFoundf("no vaccines found for region %s", ranking.Region)
+	}
+	top := ranking.Vaccines[0]
+
 	return &GetMostUsedVaccineOutput{
-		Vaccine: *mostUsedVaccine,
-		Usage:   usage,
+		Vaccine: top.Vaccine,
+		Usage:   top.Countries,
 	}, nil
 }
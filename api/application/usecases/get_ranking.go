@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetRankingUseCase interface {
+	Execute(ctx context.Context, input GetRankingInput) (*GetRankingOutput, error)
+}
+
+// GetRankingInput selects a leaderboard. An empty Region ranks every country.
+type GetRankingInput struct {
+	Metric valueobjects.RankingMetric
+	Date   time.Time
+	Mode   valueobjects.DateMode
+	Order  valueobjects.SortOrder
+	Region string
+	Limit  int
+}
+
+type GetRankingOutput struct {
+	Metric  valueobjects.RankingMetric
+	Date    string
+	Order   valueobjects.SortOrder
+	Region  string `json:",omitempty"`
+	Entries []RankingEntry
+}
+
+// RankingEntry is one row of a leaderboard. Countries with the same value
+// share a rank, and the next rank skips the tied positions, so Tied marks
+// every entry whose value another entry also has.
+type RankingEntry struct {
+	Rank int
+	Tied bool
+	Observation
+	Country entities.Country
+	Region  string
+	Value   float64
+}
+
+type getRankingUseCase struct {
+	rankingRepo repositories.RankingRepository
+}
+
+func NewGetRankingUseCase(repo repositories.RankingRepository) GetRankingUseCase {
+	return &getRankingUseCase{
+		rankingRepo: repo,
+	}
+}
+
+func (uc *getRankingUseCase) Execute(ctx context.Context, input GetRankingInput) (*GetRankingOutput, error) {
+	// One extra entry tells whether the last one is tied with a country
+	// that did not make the cut
+	entries, err := uc.rankingRepo.GetRanking(ctx, valueobjects.RankingQuery{
+		Metric: input.Metric,
+		Date:   input.Date,
+		Mode:   input.Mode,
+		Order:  input.Order,
+		Region: input.Region,
+		Limit:  input.Limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := rankEntries(entries, input.Date)
+	if len(ranked) > input.Limit {
+		ranked = ranked[:input.Limit]
+	}
+
+	return &GetRankingOutput{
+		Metric:  input.Metric,
+		Date:    input.Date.Format("2006-01-02"),
+		Order:   input.Order,
+		Region:  input.Region,
+		Entries: ranked,
+	}, nil
+}
+
+// rankEntries numbers sorted entries with standard competition ranking
+// (1, 2, 2, 4).
+func rankEntries(entries []valueobjects.RankingEntry, requested time.Time) []RankingEntry {
+	ranked := make([]RankingEntry, len(entries))
+	for i, entry := range entries {
+		ranked[i] = RankingEntry{
+			Rank:        i + 1,
+			Observation: newObservation(requested, entry.ReportedOn),
+			Country:     entry.Country,
+			Region:      entry.Region,
+			Value:       entry.Value,
+		}
+
+		if i > 0 && entry.Value == entries[i-1].Value {
+			ranked[i].Rank = ranked[i-1].Rank
+			ranked[i].Tied = true
+			ranked[i-1].Tied = true
+		}
+	}
+	return ranked
+}
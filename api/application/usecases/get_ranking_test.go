@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func TestRankEntries(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2023-01-08")
+	values := []float64{30, 20, 20, 10, 5, 5}
+
+	entries := make([]valueobjects.RankingEntry, len(values))
+	for i, value := range values {
+		entries[i] = valueobjects.RankingEntry{Value: value, ReportedOn: date.AddDate(0, 0, -i)}
+	}
+
+	ranked := rankEntries(entries, date)
+
+	wantRanks := []int{1, 2, 2, 4, 5, 5}
+	wantTied := []bool{false, true, true, false, true, true}
+	for i, entry := range ranked {
+		if entry.Rank != wantRanks[i] || entry.Tied != wantTied[i] {
+			t.Errorf("entry %d: got rank %d tied %t, want rank %d tied %t", i, entry.Rank, entry.Tied, wantRanks[i], wantTied[i])
+		}
+		if entry.StalenessDays != i {
+			t.Errorf("entry %d: got staleness %d, want %d", i, entry.StalenessDays, i)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetRegionCompaniesUseCase interface {
+	Execute(ctx context.Context, input GetRegionCompaniesInput) (*GetRegionCompaniesOutput, error)
+}
+
+type GetRegionCompaniesInput struct {
+	RegionName string
+}
+
+// GetRegionCompaniesOutput lists the manufacturers whose vaccines some
+// country of Region uses, with their adoption among its MemberCountries.
+type GetRegionCompaniesOutput struct {
+	Region          string
+	MemberCountries int
+	Companies       []CompanyOutput
+}
+
+type getRegionCompaniesUseCase struct {
+	companyRepo repositories.CompanyRepository
+}
+
+func NewGetRegionCompaniesUseCase(repo repositories.CompanyRepository) GetRegionCompaniesUseCase {
+	return &getRegionCompaniesUseCase{
+		companyRepo: repo,
+	}
+}
+
+func (uc *getRegionCompaniesUseCase) Execute(ctx context.Context, input GetRegionCompaniesInput) (*GetRegionCompaniesOutput, error) {
+	adoption, err := uc.companyRepo.GetRegionCompanies(ctx, input.RegionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRegionCompaniesOutput{
+		Region:          strings.TrimSpace(input.RegionName),
+		MemberCountries: adoption.MemberCountries,
+		Companies:       companyOutputs(adoption),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type stubCompanyRepo struct {
+	repositories.CompanyRepository
+	adoption *valueobjects.CompanyAdoption
+}
+
+func (r stubCompanyRepo) GetRegionCompanies(ctx context.Context, regionName string) (*valueobjects.CompanyAdoption, error) {
+	return r.adoption, nil
+}
+
+func TestGetRegionCompanies(t *testing.T) {
+	adoption := &valueobjects.CompanyAdoption{
+		MemberCountries: 4,
+		Companies: []valueobjects.CompanyUsage{
+			{Company: entities.Company{Name: "Pfizer BioNTech"}, Products: 3, ProductsUsed: 2, Countries: 3},
+			{Company: entities.Company{Name: "Sinovac"}, Products: 1, ProductsUsed: 1, Countries: 2},
+		},
+	}
+	uc := NewGetRegionCompaniesUseCase(stubCompanyRepo{adoption: adoption})
+
+	output, err := uc.Execute(context.Background(), GetRegionCompaniesInput{RegionName: " AMRO "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Region != "AMRO" || output.MemberCountries != 4 {
+		t.Errorf("got region %q with %d members, want AMRO with 4", output.Region, output.MemberCountries)
+	}
+	want := []CompanyOutput{
+		{Name: "Pfizer BioNTech", Products: 3, ProductsUsed: 2, Countries: 3, Share: 0.75},
+		{Name: "Sinovac", Products: 1, ProductsUsed: 1, Countries: 2, Share: 0.5},
+	}
+	if len(output.Companies) != len(want) {
+		t.Fatalf("got %d companies, want %d", len(output.Companies), len(want))
+	}
+	for i, company := range output.Companies {
+		if company != want[i] {
+			t.Errorf("company %d: got %+v, want %+v", i, company, want[i])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetRegionCountriesUseCase interface {
+	Execute(ctx context.Context, input GetRegionCountriesInput) (*GetRegionCountriesOutput, error)
+}
+
+type GetRegionCountriesInput struct {
+	RegionName string
+}
+
+type GetRegionCountriesOutput struct {
+	Region    string
+	Countries []entities.Country
+}
+
+type getRegionCountriesUseCase struct {
+	regionRepo repositories.RegionRepository
+}
+
+func NewGetRegionCountriesUseCase(repo repositories.RegionRepository) GetRegionCountriesUseCase {
+	return &getRegionCountriesUseCase{
+		regionRepo: repo,
+	}
+}
+
+func (uc *getRegionCountriesUseCase) Execute(ctx context.Context, input GetRegionCountriesInput) (*GetRegionCountriesOutput, error) {
+	countries, err := uc.regionRepo.GetRegionCountries(ctx, input.RegionName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRegionCountriesOutput{
+		Region:    input.RegionName,
+		Countries: countries,
+	}, nil
+}
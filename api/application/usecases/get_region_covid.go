@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetRegionCovidUseCase interface {
+	Execute(ctx context.Context, input GetRegionCovidInput) (*GetRegionCovidOutput, error)
+}
+
+type GetRegionCovidInput struct {
+	RegionName string
+	Date       time.Time
+	Mode       valueobjects.DateMode
+}
+
+// GetRegionCovidOutput sums the reports of the countries of a region. Counts
+// a country did not report are left out of the sums, and countries without
+// any report are listed in NotReporting.
+type GetRegionCovidOutput struct {
+	Region             string
+	Date               string
+	MemberCountries    int
+	ReportingCountries int
+	ReportingShare     float64
+	CumulativeCases    int
+	NewCases           int
+	CumulativeDeaths   int
+	NewDeaths          int
+	Countries          []RegionCovidContribution
+	NotReporting       []entities.Country
+}
+
+// RegionCovidContribution is one country's report and its share of the
+// region's cumulative cases and deaths.
+type RegionCovidContribution struct {
+	Observation
+	Country          entities.Country
+	CumulativeCases  *int
+	NewCases         *int
+	CumulativeDeaths *int
+	NewDeaths        *int
+	CaseShare        *float64
+	DeathShare       *float64
+}
+
+type getRegionCovidUseCase struct {
+	regionRepo repositories.RegionRepository
+}
+
+func NewGetRegionCovidUseCase(repo repositories.RegionRepository) GetRegionCovidUseCase {
+	return &getRegionCovidUseCase{
+		regionRepo: repo,
+	}
+}
+
+func (uc *getRegionCovidUseCase) Execute(ctx context.Context, input GetRegionCovidInput) (*GetRegionCovidOutput, error) {
+	countries, err := uc.regionRepo.GetRegionCovidStats(ctx, input.RegionName, input.Date, input.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	output := aggregateRegionCovid(countries, input.Date)
+	output.Region = input.RegionName
+	output.Date = input.Date.Format("2006-01-02")
+
+	return output, nil
+}
+
+// aggregateRegionCovid sums the reports of countries and works out each
+// reporting country's share, listing the largest contributors first.
+func aggregateRegionCovid(countries []valueobjects.CountryCovidStats, requested time.Time) *GetRegionCovidOutput {
+	output := &GetRegionCovidOutput{
+		MemberCountries: len(countries),
+		Countries:       []RegionCovidContribution{},
+		NotReporting:    []entities.Country{},
+	}
+
+	for _, country := range countries {
+		if country.Stats == nil {
+			output.NotReporting = append(output.NotReporting, country.Country)
+			continue
+		}
+
+		stats := country.Stats
+		output.ReportingCountries++
+		output.CumulativeCases += valueOrZero(stats.CumulativeCases)
+		output.NewCases += valueOrZero(stats.NewCases)
+		output.CumulativeDeaths += valueOrZero(stats.CumulativeDeaths)
+		output.NewDeaths += valueOrZero(stats.NewDeaths)
+
+		output.Countries = append(output.Countries, RegionCovidContribution{
+			Observation:      newObservation(requested, stats.ReportedOn),
+			Country:          country.Country,
+			CumulativeCases:  stats.CumulativeCases,
+			NewCases:         stats.NewCases,
+			CumulativeDeaths: stats.CumulativeDeaths,
+			NewDeaths:        stats.NewDeaths,
+		})
+	}
+
+	if output.MemberCountries > 0 {
+		output.ReportingShare = float64(output.ReportingCountries) / float64(output.MemberCountries)
+	}
+
+	for i := range output.Countries {
+		contribution := &output.Countries[i]
+		contribution.CaseShare = share(contribution.CumulativeCases, output.CumulativeCases)
+		contribution.DeathShare = share(contribution.CumulativeDeaths, output.CumulativeDeaths)
+	}
+
+	sort.SliceStable(output.Countries, func(i, j int) bool {
+		return valueOrZero(output.Countries[i].CumulativeCases) > valueOrZero(output.Countries[j].CumulativeCases)
+	})
+
+	return output
+}
+
+func valueOrZero(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+// share is part's fraction of total, or nil when part is unknown or total is
+// zero.
+func share(part *int, total int) *float64 {
+	if part == nil || total == 0 {
+		return nil
+	}
+	s := float64(*part) / float64(total)
+	return &s
+}
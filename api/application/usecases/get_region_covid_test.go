@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func TestAggregateRegionCovid(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2023-01-08")
+	stats := func(cumulativeCases, cumulativeDeaths *int) *valueobjects.CovidStats {
+		s := valueobjects.NewCovidStats(cumulativeDeaths, nil, cumulativeCases, nil)
+		s.ReportedOn = date
+		return &s
+	}
+
+	countries := []valueobjects.CountryCovidStats{
+		{Country: entities.Country{Code: "ARG"}, Stats: stats(intPtr(25), intPtr(1))},
+		{Country: entities.Country{Code: "BRA"}, Stats: stats(intPtr(75), nil)},
+		{Country: entities.Country{Code: "CHL"}},
+		{Country: entities.Country{Code: "URY"}, Stats: stats(nil, intPtr(3))},
+	}
+
+	output := aggregateRegionCovid(countries, date)
+
+	if output.MemberCountries != 4 || output.ReportingCountries != 3 || output.ReportingShare != 0.75 {
+		t.Errorf("got %d of %d reporting (%v), want 3 of 4 (0.75)", output.ReportingCountries, output.MemberCountries, output.ReportingShare)
+	}
+	if output.CumulativeCases != 100 || output.CumulativeDeaths != 4 {
+		t.Errorf("got %d cases and %d deaths, want 100 and 4", output.CumulativeCases, output.CumulativeDeaths)
+	}
+	if len(output.NotReporting) != 1 || output.NotReporting[0].Code != "CHL" {
+		t.Errorf("got not reporting %v, want CHL", output.NotReporting)
+	}
+
+	wantCodes := []string{"BRA", "ARG", "URY"}
+	wantCaseShares := []*float64{floatPtr(0.75), floatPtr(0.25), nil}
+	wantDeathShares := []*float64{nil, floatPtr(0.25), floatPtr(0.75)}
+	for i, contribution := range output.Countries {
+		if contribution.Country.Code != wantCodes[i] {
+			t.Errorf("contribution %d: got %s, want %s", i, contribution.Country.Code, wantCodes[i])
+		}
+		if !equalShare(contribution.CaseShare, wantCaseShares[i]) || !equalShare(contribution.DeathShare, wantDeathShares[i]) {
+			t.Errorf("contribution %d: got shares %v and %v", i, contribution.CaseShare, contribution.DeathShare)
+		}
+	}
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func equalShare(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
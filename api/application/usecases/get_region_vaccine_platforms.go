@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetRegionVaccinePlatformsUseCase interface {
+	Execute(ctx context.Context, input GetRegionVaccinePlatformsInput) (*GetRegionVaccinePlatformsOutput, error)
+}
+
+type GetRegionVaccinePlatformsInput struct {
+	RegionName string
+}
+
+// GetRegionVaccinePlatformsOutput is the platform mix of a region, platforms
+// used by the most countries first. Reporting countries are the members that
+// use any vaccine.
+type GetRegionVaccinePlatformsOutput struct {
+	Region             string
+	MemberCountries    int
+	ReportingCountries int
+	Platforms          []RegionVaccinePlatform
+}
+
+// RegionVaccinePlatform is a platform with the countries of the region that
+// use at least one of its vaccines, their share of the member countries and
+// those vaccines, ordered by product. A country using several platforms
+// counts for each, so shares do not add up to 1.
+type RegionVaccinePlatform struct {
+	Platform  string
+	Countries int
+	Share     float64
+	Products  []string
+}
+
+type getRegionVaccinePlatformsUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetRegionVaccinePlatformsUseCase(repo repositories.VaccineRepository) GetRegionVaccinePlatformsUseCase {
+	return &getRegionVaccinePlatformsUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getRegionVaccinePlatformsUseCase) Execute(ctx context.Context, input GetRegionVaccinePlatformsInput) (*GetRegionVaccinePlatformsOutput, error) {
+	usage, err := uc.vaccineRepo.GetRegionVaccineUsage(ctx, input.RegionName)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetRegionVaccinePlatformsOutput{
+		Region:          strings.TrimSpace(input.RegionName),
+		MemberCountries: len(usage),
+		Platforms:       []RegionVaccinePlatform{},
+	}
+
+	byPlatform := make(map[string]*RegionVaccinePlatform)
+	products := make(map[string]map[string]struct{})
+	for _, country := range usage {
+		if len(country.Vaccines) > 0 {
+			output.ReportingCountries++
+		}
+
+		counted := make(map[string]bool)
+		for _, vaccine := range country.Vaccines {
+			platform := platformOf(vaccine)
+			group, ok := byPlatform[platform]
+			if !ok {
+				group = &RegionVaccinePlatform{Platform: platform}
+				byPlatform[platform] = group
+				products[platform] = make(map[string]struct{})
+			}
+			if !counted[platform] {
+				group.Countries++
+				counted[platform] = true
+			}
+			products[platform][vaccine.Product] = struct{}{}
+		}
+	}
+
+	for platform, group := range byPlatform {
+		group.Share = fraction(float64(group.Countries), float64(output.MemberCountries))
+		for product := range products[platform] {
+			group.Products = append(group.Products, product)
+		}
+		sort.Strings(group.Products)
+		output.Platforms = append(output.Platforms, *group)
+	}
+	sort.Slice(output.Platforms, func(i, j int) bool {
+		a, b := output.Platforms[i], output.Platforms[j]
+		if a.Countries != b.Countries {
+			return a.Countries > b.Countries
+		}
+		return a.Platform < b.Platform
+	})
+
+	return output, nil
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func TestGetRegionVaccinePlatforms(t *testing.T) {
+	pfizer := entities.Vaccine{Product: "Pfizer BioNTech - Comirnaty", Platform: "mrna"}
+	moderna := entities.Vaccine{Product: "Moderna - Spikevax", Platform: "mrna"}
+	sinovac := entities.Vaccine{Product: "SinoVac - CoronaVac", Platform: "inactivated"}
+	astra := entities.Vaccine{Product: "AstraZeneca - Vaxzevria", Platform: "viral_vector"}
+
+	usage := []valueobjects.CountryVaccineUsage{
+		{Country: entities.Country{Code: "ARG"}, Vaccines: []entities.Vaccine{astra, moderna, pfizer}},
+		{Country: entities.Country{Code: "BRA"}, Vaccines: []entities.Vaccine{sinovac, pfizer}},
+		{Country: entities.Country{Code: "CHL"}, Vaccines: []entities.Vaccine{sinovac}},
+		{Country: entities.Country{Code: "URY"}},
+	}
+	uc := NewGetRegionVaccinePlatformsUseCase(stubRegionVaccineRepo{usage: usage})
+
+	output, err := uc.Execute(context.Background(), GetRegionVaccinePlatformsInput{RegionName: " AMRO "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []RegionVaccinePlatform{
+		{Platform: "inactivated", Countries: 2, Share: 0.5, Products: []string{"SinoVac - CoronaVac"}},
+		{Platform: "mrna", Countries: 2, Share: 0.5, Products: []string{"Moderna - Spikevax", "Pfizer BioNTech - Comirnaty"}},
+		{Platform: "viral_vector", Countries: 1, Share: 0.25, Products: []string{"AstraZeneca - Vaxzevria"}},
+	}
+	if output.Region != "AMRO" || output.MemberCountries != 4 || output.ReportingCountries != 3 {
+		t.Errorf("got %q with %d members and %d reporting, want AMRO with 4 and 3",
+			output.Region, output.MemberCountries, output.ReportingCountries)
+	}
+	if !reflect.DeepEqual(output.Platforms, want) {
+		t.Errorf("got platforms %+v, want %+v", output.Platforms, want)
+	}
+}
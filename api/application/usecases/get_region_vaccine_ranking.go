@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetRegionVaccineRankingUseCase interface {
+	Execute(ctx context.Context, input GetRegionVaccineRankingInput) (*GetRegionVaccineRankingOutput, error)
+}
+
+// GetRegionVaccineRankingInput selects the Limit vaccines used the most in a
+// region, measured by Weight.
+type GetRegionVaccineRankingInput struct {
+	RegionName string
+	Weight     valueobjects.VaccineWeight
+	Limit      int
+}
+
+// GetRegionVaccineRankingOutput ranks the vaccines of a region. Reporting
+// countries are the ones with data for the weight: any vaccine when counting
+// countries, any vaccine and a total of doses when estimating doses.
+type GetRegionVaccineRankingOutput struct {
+	Region             string
+	Weight             valueobjects.VaccineWeight
+	MemberCountries    int
+	ReportingCountries int
+	Vaccines           []RegionVaccineRankingEntry
+}
+
+// RegionVaccineRankingEntry is a vaccine with the countries of the region
+// that adopted it and its share of the region: of its member countries when
+// counting countries, of the doses reported by the countries using any
+// vaccine when estimating doses. EstimatedDoses is only set when estimating
+// doses. Vaccines with the same usage share a rank and are marked as tied;
+// they are listed by adopting countries and then by product.
+type RegionVaccineRankingEntry struct {
+	Rank           int
+	Tied           bool
+	Vaccine        entities.Vaccine
+	Countries      int
+	EstimatedDoses *int
+	Share          float64
+}
+
+type getRegionVaccineRankingUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetRegionVaccineRankingUseCase(repo repositories.VaccineRepository) GetRegionVaccineRankingUseCase {
+	return &getRegionVaccineRankingUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getRegionVaccineRankingUseCase) Execute(ctx context.Context, input GetRegionVaccineRankingInput) (*GetRegionVaccineRankingOutput, error) {
+	if input.Limit < 1 {
+		return nil, domain.InvalidInputf("limit %d is not positive", input.Limit)
+	}
+
+	usage, err := uc.vaccineRepo.GetRegionVaccineUsage(ctx, input.RegionName)
+	if err != nil {
+		return nil, err
+	}
+
+	output := rankRegionVaccines(usage, input.Weight)
+	output.Region = strings.TrimSpace(input.RegionName)
+	if len(output.Vaccines) > input.Limit {
+		output.Vaccines = output.Vaccines[:input.Limit]
+	}
+	return output, nil
+}
+
+// regionVaccineUsage accumulates the usage of one vaccine across a region.
+type regionVaccineUsage struct {
+	vaccine   entities.Vaccine
+	countries int
+	doses     float64
+}
+
+// rankRegionVaccines measures every vaccine used in the region by weight and
+// ranks them, largest usage first.
+func rankRegionVaccines(usage []valueobjects.CountryVaccineUsage, weight valueobjects.VaccineWeight) *GetRegionVaccineRankingOutput {
+	output := &GetRegionVaccineRankingOutput{
+		Weight:          weight,
+		MemberCountries: len(usage),
+		Vaccines:        []RegionVaccineRankingEntry{},
+	}
+
+	byProduct := make(map[string]*regionVaccineUsage)
+	totalDoses := 0.0
+	for _, country := range usage {
+		reporting := len(country.Vaccines) > 0
+		if weight == valueobjects.WeightEstimatedDoses {
+			reporting = reporting && country.Doses != nil
+		}
+		if reporting {
+			output.ReportingCountries++
+		}
+
+		// Doses of countries that report no vaccine cannot be assigned to any,
+		// so they are left out of the shares too
+		if country.Doses != nil && len(country.Vaccines) > 0 {
+			totalDoses += float64(*country.Doses)
+		}
+		for _, vaccine := range country.Vaccines {
+			used, ok := byProduct[vaccine.Product]
+			if !ok {
+				used = &regionVaccineUsage{vaccine: vaccine}
+				byProduct[vaccine.Product] = used
+			}
+			used.countries++
+			// The data has no doses per vaccine, so each is assumed an equal part
+			if country.Doses != nil {
+				used.doses += float64(*country.Doses) / float64(len(country.Vaccines))
+			}
+		}
+	}
+
+	vaccines := make([]*regionVaccineUsage, 0, len(byProduct))
+	for _, used := range byProduct {
+		vaccines = append(vaccines, used)
+	}
+
+	value := func(used *regionVaccineUsage) float64 {
+		// Estimated doses are compared as whole doses, so rounding cannot break ties
+		if weight == valueobjects.WeightEstimatedDoses {
+			return math.Round(used.doses)
+		}
+		return float64(used.countries)
+	}
+	sort.Slice(vaccines, func(i, j int) bool {
+		a, b := vaccines[i], vaccines[j]
+		if value(a) != value(b) {
+			return value(a) > value(b)
+		}
+		if a.countries != b.countries {
+			return a.countries > b.countries
+		}
+		return a.vaccine.Product < b.vaccine.Product
+	})
+
+	for i, used := range vaccines {
+		entry := RegionVaccineRankingEntry{
+			Rank:      i + 1,
+			Vaccine:   used.vaccine,
+			Countries: used.countries,
+		}
+		if weight == valueobjects.WeightEstimatedDoses {
+			doses := int(math.Round(used.doses))
+			entry.EstimatedDoses = &doses
+			entry.Share = fraction(used.doses, totalDoses)
+		} else {
+			entry.Share = fraction(float64(used.countries), float64(len(usage)))
+		}
+
+		if i > 0 && value(used) == value(vaccines[i-1]) {
+			entry.Rank = output.Vaccines[i-1].Rank
+			entry.Tied = true
+			output.Vaccines[i-1].Tied = true
+		}
+		output.Vaccines = append(output.Vaccines, entry)
+	}
+	return output
+}
+
+// fraction returns part of total, or 0 when the total is 0.
+func fraction(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func amroVaccineUsage() []valueobjects.CountryVaccineUsage {
+	pfizer := entities.Vaccine{Product: "Pfizer BioNTech - Comirnaty", Company: "Pfizer BioNTech"}
+	sinovac := entities.Vaccine{Product: "SinoVac - CoronaVac", Company: "SinoVac"}
+	astra := entities.Vaccine{Product: "AstraZeneca - Vaxzevria", Company: "AstraZeneca"}
+	doses := func(n int) *int { return &n }
+
+	return []valueobjects.CountryVaccineUsage{
+		{Country: entities.Country{Code: "ARG"}, Vaccines: []entities.Vaccine{astra, pfizer}, Doses: doses(1000)},
+		{Country: entities.Country{Code: "BRA"}, Vaccines: []entities.Vaccine{sinovac}, Doses: doses(3000)},
+		{Country: entities.Country{Code: "CHL"}, Vaccines: []entities.Vaccine{pfizer, sinovac}},
+		{Country: entities.Country{Code: "URY"}, Doses: doses(4000)},
+	}
+}
+
+func TestRankRegionVaccines(t *testing.T) {
+	tests := []struct {
+		name          string
+		weight        valueobjects.VaccineWeight
+		wantReporting int
+		wantProducts  []string
+		wantRanks     []int
+		wantTied      []bool
+		wantDoses     []int
+		wantShares    []float64
+	}{
+		{
+			name:          "countries",
+			weight:        valueobjects.WeightCountries,
+			wantReporting: 3,
+			wantProducts:  []string{"Pfizer BioNTech - Comirnaty", "SinoVac - CoronaVac", "AstraZeneca - Vaxzevria"},
+			wantRanks:     []int{1, 1, 3},
+			wantTied:      []bool{true, true, false},
+			wantShares:    []float64{0.5, 0.5, 0.25},
+		},
+		{
+			name:          "estimated doses",
+			weight:        valueobjects.WeightEstimatedDoses,
+			wantReporting: 2,
+			wantProducts:  []string{"SinoVac - CoronaVac", "Pfizer BioNTech - Comirnaty", "AstraZeneca - Vaxzevria"},
+			wantRanks:     []int{1, 2, 2},
+			wantTied:      []bool{false, true, true},
+			wantDoses:     []int{3000, 500, 500},
+			wantShares:    []float64{0.75, 0.125, 0.125},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := rankRegionVaccines(amroVaccineUsage(), tt.weight)
+
+			if output.MemberCountries != 4 || output.ReportingCountries != tt.wantReporting {
+				t.Errorf("got %d members and %d reporting, want 4 and %d", output.MemberCountries, output.ReportingCountries, tt.wantReporting)
+			}
+			if len(output.Vaccines) != len(tt.wantProducts) {
+				t.Fatalf("got %d vaccines, want %d", len(output.Vaccines), len(tt.wantProducts))
+			}
+			for i, entry := range output.Vaccines {
+				if entry.Vaccine.Product != tt.wantProducts[i] || entry.Rank != tt.wantRanks[i] || entry.Tied != tt.wantTied[i] {
+					t.Errorf("entry %d: got %s rank %d tied %t, want %s rank %d tied %t", i,
+						entry.Vaccine.Product, entry.Rank, entry.Tied, tt.wantProducts[i], tt.wantRanks[i], tt.wantTied[i])
+				}
+				if entry.Share != tt.wantShares[i] {
+					t.Errorf("entry %d: got share %v, want %v", i, entry.Share, tt.wantShares[i])
+				}
+				switch {
+				case tt.wantDoses == nil && entry.EstimatedDoses != nil:
+					t.Errorf("entry %d: got doses %d, want none", i, *entry.EstimatedDoses)
+				case tt.wantDoses != nil && (entry.EstimatedDoses == nil || *entry.EstimatedDoses != tt.wantDoses[i]):
+					t.Errorf("entry %d: got doses %v, want %d", i, entry.EstimatedDoses, tt.wantDoses[i])
+				}
+			}
+		})
+	}
+}
+
+type stubRegionVaccineRepo struct {
+	stubVaccineRepo
+	usage []valueobjects.CountryVaccineUsage
+}
+
+func (r stubRegionVaccineRepo) GetRegionVaccineUsage(ctx context.Context, regionName string) ([]valueobjects.CountryVaccineUsage, error) {
+	return r.usage, nil
+}
+
+func TestGetRegionVaccineRanking(t *testing.T) {
+	uc := NewGetRegionVaccineRankingUseCase(stubRegionVaccineRepo{usage: amroVaccineUsage()})
+
+	output, err := uc.Execute(context.Background(), GetRegionVaccineRankingInput{RegionName: " AMRO ", Weight: valueobjects.WeightCountries, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output.Region != "AMRO" || len(output.Vaccines) != 2 {
+		t.Errorf("got region %q with %d vaccines, want AMRO with 2", output.Region, len(output.Vaccines))
+	}
+
+	_, err = uc.Execute(context.Background(), GetRegionVaccineRankingInput{RegionName: "AMRO", Limit: 0})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("got error %v for limit 0, want invalid input", err)
+	}
+}
+
+func TestGetMostUsedVaccine(t *testing.T) {
+	t.Run("breaks ties by product", func(t *testing.T) {
+		uc := NewGetMostUsedVaccineUseCase(NewGetRegionVaccineRankingUseCase(stubRegionVaccineRepo{usage: amroVaccineUsage()}))
+
+		output, err := uc.Execute(context.Background(), GetMostUsedVaccineInput{RegionName: "AMRO"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.Vaccine.Product != "Pfizer BioNTech - Comirnaty" || output.Usage != 2 {
+			t.Errorf("got %s used by %d, want Pfizer BioNTech - Comirnaty used by 2", output.Vaccine.Product, output.Usage)
+		}
+	})
+
+	t.Run("no vaccines", func(t *testing.T) {
+		usage := []valueobjects.CountryVaccineUsage{{Country: entities.Country{Code: "URY"}}}
+		uc := NewGetMostUsedVaccineUseCase(NewGetRegionVaccineRankingUseCase(stubRegionVaccineRepo{usage: usage}))
+
+		_, err := uc.Execute(context.Background(), GetMostUsedVaccineInput{RegionName: "AMRO"})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("got error %v, want not found", err)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetRegionsUseCase interface {
+	Execute(ctx context.Context) (*GetRegionsOutput, error)
+}
+
+type GetRegionsOutput struct {
+	Regions []RegionOutput
+}
+
+type RegionOutput struct {
+	Name      string
+	Countries int
+}
+
+type getRegionsUseCase struct {
+	regionRepo repositories.RegionRepository
+}
+
+func NewGetRegionsUseCase(repo repositories.RegionRepository) GetRegionsUseCase {
+	return &getRegionsUseCase{
+		regionRepo: repo,
+	}
+}
+
+func (uc *getRegionsUseCase) Execute(ctx context.Context) (*GetRegionsOutput, error) {
+	regions, err := uc.regionRepo.ListRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetRegionsOutput{
+		Regions: make([]RegionOutput, len(regions)),
+	}
+	for i, region := range regions {
+		output.Regions[i] = RegionOutput{
+			Name:      region.Region.Name,
+			Countries: region.Countries,
+		}
+	}
+
+	return output, nil
+}
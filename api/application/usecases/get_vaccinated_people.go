@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type GetVaccinatedPeopleUseCase interface {
@@ -14,13 +15,15 @@ type GetVaccinatedPeopleUseCase interface {
 type GetVaccinatedPeopleInput struct {
 	CountryCode string
 	Date        time.Time
+	Mode        valueobjects.DateMode
 }
 
 type GetVaccinatedPeopleOutput struct {
-	PersonsVaccinated1PlusDose       int
-	PersonsVaccinated1PlusDosePer100 int
-	TotalVaccinations                int
-	TotalVaccinationsPer100          int
+	Observation
+	PersonsVaccinated1PlusDose       *int
+	PersonsVaccinated1PlusDosePer100 *float64
+	TotalVaccinations                *int
+	TotalVaccinationsPer100          *float64
 }
 
 type getVaccinatedPeopleUseCase struct {
@@ -34,12 +37,13 @@ func NewGetVaccinatedPeopleUseCase(repo repositories.VaccinationStatsRepository)
 }
 
 func (uc *getVaccinatedPeopleUseCase) Execute(ctx context.Context, input GetVaccinatedPeopleInput) (*GetVaccinatedPeopleOutput, error) {
-	stats, err := uc.vaccinationStatsRepo.GetVaccinatedPeople(ctx, input.CountryCode, input.Date)
+	stats, err := uc.vaccinationStatsRepo.GetVaccinatedPeople(ctx, input.CountryCode, input.Date, input.Mode)
 	if err != nil {
 		return nil, err
 	}
 
 	return &GetVaccinatedPeopleOutput{
+		Observation:                      newObservation(input.Date, stats.ReportedOn),
 		PersonsVaccinated1PlusDose:       stats.PersonsVaccinated1PlusDose,
 		PersonsVaccinated1PlusDosePer100: stats.PersonsVaccinated1PlusDosePer100,
 		TotalVaccinations:                stats.TotalVaccinations,
@@ -0,0 +1,181 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type GetVaccineAdoptionUseCase interface {
+	Execute(ctx context.Context, input GetVaccineAdoptionInput) (*GetVaccineAdoptionOutput, error)
+}
+
+type GetVaccineAdoptionInput struct {
+	Product  string
+	Interval valueobjects.Interval
+}
+
+// GetVaccineAdoptionOutput describes how a vaccine spread across countries:
+// overall, over time and by region. Regions are ordered by name and leave
+// out countries that belong to none.
+type GetVaccineAdoptionOutput struct {
+	Vaccine  entities.Vaccine
+	Interval valueobjects.Interval
+	VaccineAdoptionSummary
+	Timeline []VaccineAdoptionPoint
+	Regions  []RegionVaccineAdoption
+}
+
+// VaccineAdoptionSummary counts the countries that use a vaccine and those
+// that reported when they started. FirstAdopter is the earliest to start,
+// by country code among the ones that started the same day.
+// MedianDaysToFirstUse is the median of the days each country took from
+// authorizing the vaccine to using it, negative when it was used first. They
+// are nil when no country reported the dates they need.
+type VaccineAdoptionSummary struct {
+	AdoptingCountries    int
+	DatedCountries       int
+	FirstAdopter         *VaccineFirstAdopter
+	MedianDaysToFirstUse *float64
+}
+
+type VaccineFirstAdopter struct {
+	Country   entities.Country
+	Region    string
+	StartDate string
+}
+
+// VaccineAdoptionPoint covers one period of the timeline, starting on Date,
+// with the countries that started using the vaccine in it and all that had
+// by its end. Periods without new countries are left out.
+type VaccineAdoptionPoint struct {
+	Date                string
+	NewCountries        int
+	CumulativeCountries int
+}
+
+type RegionVaccineAdoption struct {
+	Region string
+	VaccineAdoptionSummary
+}
+
+type getVaccineAdoptionUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetVaccineAdoptionUseCase(repo repositories.VaccineRepository) GetVaccineAdoptionUseCase {
+	return &getVaccineAdoptionUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getVaccineAdoptionUseCase) Execute(ctx context.Context, input GetVaccineAdoptionInput) (*GetVaccineAdoptionOutput, error) {
+	adoption, err := uc.vaccineRepo.GetVaccineCountries(ctx, input.Product, "")
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetVaccineAdoptionOutput{
+		Vaccine:                adoption.Vaccine,
+		Interval:               input.Interval,
+		VaccineAdoptionSummary: summarizeAdoption(adoption.Countries),
+		Timeline:               adoptionTimeline(adoption.Countries, input.Interval),
+		Regions:                []RegionVaccineAdoption{},
+	}
+
+	byRegion := make(map[string][]valueobjects.VaccineAdopter)
+	for _, adopter := range adoption.Countries {
+		if adopter.Region != "" {
+			byRegion[adopter.Region] = append(byRegion[adopter.Region], adopter)
+		}
+	}
+	for region, adopters := range byRegion {
+		output.Regions = append(output.Regions, RegionVaccineAdoption{
+			Region:                 region,
+			VaccineAdoptionSummary: summarizeAdoption(adopters),
+		})
+	}
+	sort.Slice(output.Regions, func(i, j int) bool {
+		return output.Regions[i].Region < output.Regions[j].Region
+	})
+
+	return output, nil
+}
+
+// summarizeAdoption summarizes the adopters of a vaccine.
+func summarizeAdoption(adopters []valueobjects.VaccineAdopter) VaccineAdoptionSummary {
+	summary := VaccineAdoptionSummary{AdoptingCountries: len(adopters)}
+
+	var first *valueobjects.VaccineAdopter
+	var daysToFirstUse []float64
+	for i, adopter := range adopters {
+		if adopter.StartDate == nil {
+			continue
+		}
+		summary.DatedCountries++
+
+		if first == nil || adopter.StartDate.Before(*first.StartDate) ||
+			(adopter.StartDate.Equal(*first.StartDate) && adopter.Country.Code < first.Country.Code) {
+			first = &adopters[i]
+		}
+		if adopter.AuthorizationDate != nil {
+			daysToFirstUse = append(daysToFirstUse, adopter.StartDate.Sub(*adopter.AuthorizationDate).Hours()/24)
+		}
+	}
+
+	if first != nil {
+		summary.FirstAdopter = &VaccineFirstAdopter{
+			Country:   first.Country,
+			Region:    first.Region,
+			StartDate: first.StartDate.Format("2006-01-02"),
+		}
+	}
+	summary.MedianDaysToFirstUse = median(daysToFirstUse)
+
+	return summary
+}
+
+// adoptionTimeline counts the adopters that reported a start date by the
+// period of interval they started in.
+func adoptionTimeline(adopters []valueobjects.VaccineAdopter, interval valueobjects.Interval) []VaccineAdoptionPoint {
+	var starts []time.Time
+	for _, adopter := range adopters {
+		if adopter.StartDate != nil {
+			starts = append(starts, interval.PeriodStart(*adopter.StartDate))
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	points := []VaccineAdoptionPoint{}
+	for i, start := range starts {
+		if i == 0 || !start.Equal(starts[i-1]) {
+			points = append(points, VaccineAdoptionPoint{Date: start.Format("2006-01-02")})
+		}
+		point := &points[len(points)-1]
+		point.NewCountries++
+		point.CumulativeCountries = i + 1
+	}
+
+	return points
+}
+
+// median returns the median of values, or nil when there are none.
+func median(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	result := sorted[middle]
+	if len(sorted)%2 == 0 {
+		result = (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return &result
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+func TestGetVaccineAdoption(t *testing.T) {
+	date := func(value string) *time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return &parsed
+	}
+	adoption := &valueobjects.VaccineAdoption{
+		Vaccine: entities.Vaccine{Product: "Moderna - Spikevax", Company: "Moderna", Vaccine: "Spikevax"},
+		Countries: []valueobjects.VaccineAdopter{
+			{Country: entities.Country{Code: "ARG"}, Region: "AMRO", StartDate: date("2021-02-08"), AuthorizationDate: date("2021-01-29")},
+			{Country: entities.Country{Code: "AUT"}, Region: "EURO", StartDate: date("2021-01-12"), AuthorizationDate: date("2021-01-06")},
+			{Country: entities.Country{Code: "BRA"}, Region: "AMRO"},
+			{Country: entities.Country{Code: "CAN"}, Region: "AMRO", StartDate: date("2021-01-12"), AuthorizationDate: date("2020-12-23")},
+			{Country: entities.Country{Code: "XKX"}, StartDate: date("2021-03-15")},
+		},
+	}
+	uc := NewGetVaccineAdoptionUseCase(stubVaccineCountriesRepo{adoption: adoption})
+
+	output, err := uc.Execute(context.Background(), GetVaccineAdoptionInput{Product: "Moderna - Spikevax", Interval: valueobjects.IntervalMonth})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.AdoptingCountries != 5 || output.DatedCountries != 4 {
+		t.Errorf("got %d adopting and %d dated countries, want 5 and 4", output.AdoptingCountries, output.DatedCountries)
+	}
+	if output.FirstAdopter == nil || output.FirstAdopter.Country.Code != "AUT" || output.FirstAdopter.StartDate != "2021-01-12" {
+		t.Errorf("got first adopter %+v, want AUT on 2021-01-12", output.FirstAdopter)
+	}
+	// 6, 10 and 20 days
+	if output.MedianDaysToFirstUse == nil || *output.MedianDaysToFirstUse != 10 {
+		t.Errorf("got median %v, want 10", output.MedianDaysToFirstUse)
+	}
+
+	wantTimeline := []VaccineAdoptionPoint{
+		{Date: "2021-01-01", NewCountries: 2, CumulativeCountries: 2},
+		{Date: "2021-02-01", NewCountries: 1, CumulativeCountries: 3},
+		{Date: "2021-03-01", NewCountries: 1, CumulativeCountries: 4},
+	}
+	if len(output.Timeline) != len(wantTimeline) {
+		t.Fatalf("got timeline %+v, want %+v", output.Timeline, wantTimeline)
+	}
+	for i, point := range output.Timeline {
+		if point != wantTimeline[i] {
+			t.Errorf("point %d: got %+v, want %+v", i, point, wantTimeline[i])
+		}
+	}
+
+	if len(output.Regions) != 2 || output.Regions[0].Region != "AMRO" || output.Regions[1].Region != "EURO" {
+		t.Fatalf("got regions %+v, want AMRO and EURO", output.Regions)
+	}
+	amro := output.Regions[0]
+	if amro.AdoptingCountries != 3 || amro.DatedCountries != 2 || amro.FirstAdopter.Country.Code != "CAN" {
+		t.Errorf("got AMRO %+v, want 3 adopting, 2 dated and CAN first", amro.VaccineAdoptionSummary)
+	}
+	if amro.MedianDaysToFirstUse == nil || *amro.MedianDaysToFirstUse != 15 {
+		t.Errorf("got AMRO median %v, want 15", amro.MedianDaysToFirstUse)
+	}
+}
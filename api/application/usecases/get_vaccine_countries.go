@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetVaccineCountriesUseCase interface {
+	Execute(ctx context.Context, input GetVaccineCountriesInput) (*GetVaccineCountriesOutput, error)
+}
+
+// GetVaccineCountriesInput selects the countries that use Product. An empty
+// RegionName selects them in every region.
+type GetVaccineCountriesInput struct {
+	Product    string
+	RegionName string
+}
+
+// GetVaccineCountriesOutput is a vaccine with the countries that use it,
+// ordered by country code. Region is empty when the countries were not
+// filtered by region.
+type GetVaccineCountriesOutput struct {
+	Vaccine   entities.Vaccine
+	Region    string
+	Countries []VaccineCountryOutput
+}
+
+// VaccineCountryOutput is a country that uses the vaccine, with the dates it
+// reported for it. Dates not reported are nil.
+type VaccineCountryOutput struct {
+	Country           entities.Country
+	Region            string
+	StartDate         *string
+	AuthorizationDate *string
+	EndDate           *string
+}
+
+type getVaccineCountriesUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetVaccineCountriesUseCase(repo repositories.VaccineRepository) GetVaccineCountriesUseCase {
+	return &getVaccineCountriesUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getVaccineCountriesUseCase) Execute(ctx context.Context, input GetVaccineCountriesInput) (*GetVaccineCountriesOutput, error) {
+	adoption, err := uc.vaccineRepo.GetVaccineCountries(ctx, input.Product, input.RegionName)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetVaccineCountriesOutput{
+		Vaccine:   adoption.Vaccine,
+		Region:    strings.TrimSpace(input.RegionName),
+		Countries: make([]VaccineCountryOutput, len(adoption.Countries)),
+	}
+	for i, adopter := range adoption.Countries {
+		output.Countries[i] = VaccineCountryOutput{
+			Country:           adopter.Country,
+			Region:            adopter.Region,
+			StartDate:         formatDate(adopter.StartDate),
+			AuthorizationDate: formatDate(adopter.AuthorizationDate),
+			EndDate:           formatDate(adopter.EndDate),
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type stubVaccineCountriesRepo struct {
+	stubVaccineRepo
+	adoption *valueobjects.VaccineAdoption
+}
+
+func (r stubVaccineCountriesRepo) GetVaccineCountries(ctx context.Context, product string, regionName string) (*valueobjects.VaccineAdoption, error) {
+	return r.adoption, nil
+}
+
+func TestGetVaccineCountries(t *testing.T) {
+	startDate, _ := time.Parse("2006-01-02", "2021-02-03")
+	adoption := &valueobjects.VaccineAdoption{
+		Vaccine: entities.Vaccine{Product: "Beijing CNBG - BBIBP-CorV", Company: "Beijing Bio-Institute Biological Products (CNBG)", Vaccine: "BBIBP-CorV"},
+		Countries: []valueobjects.VaccineAdopter{
+			{Country: entities.Country{Code: "ARG", Name: "Argentina"}, Region: "AMRO", StartDate: &startDate},
+			{Country: entities.Country{Code: "ATG", Name: "Antigua and Barbuda"}, Region: "AMRO"},
+		},
+	}
+	uc := NewGetVaccineCountriesUseCase(stubVaccineCountriesRepo{adoption: adoption})
+
+	output, err := uc.Execute(context.Background(), GetVaccineCountriesInput{Product: "Beijing CNBG - BBIBP-CorV", RegionName: " AMRO "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Vaccine != adoption.Vaccine || output.Region != "AMRO" {
+		t.Errorf("got vaccine %+v in region %q, want %+v in AMRO", output.Vaccine, output.Region, adoption.Vaccine)
+	}
+	if len(output.Countries) != 2 {
+		t.Fatalf("got %d countries, want 2", len(output.Countries))
+	}
+	if got := output.Countries[0].StartDate; got == nil || *got != "2021-02-03" {
+		t.Errorf("got start date %v for ARG, want 2021-02-03", got)
+	}
+	if got := output.Countries[1]; got.StartDate != nil || got.AuthorizationDate != nil || got.EndDate != nil || got.Region != "AMRO" {
+		t.Errorf("got %+v for ATG, want no dates in AMRO", got)
+	}
+}
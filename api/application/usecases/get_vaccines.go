@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+)
+
+type GetVaccinesUseCase interface {
+	Execute(ctx context.Context) (*GetVaccinesOutput, error)
+}
+
+type GetVaccinesOutput struct {
+	Vaccines []VaccineOutput
+}
+
+// VaccineOutput is a vaccine of the catalogue, with its platform fields as
+// described on entities.Vaccine, and the number of countries that use it.
+type VaccineOutput struct {
+	Product   string
+	Company   string
+	Vaccine   string
+	Platform  string
+	Doses     *int
+	WHOEUL    string
+	Countries int
+}
+
+type getVaccinesUseCase struct {
+	vaccineRepo repositories.VaccineRepository
+}
+
+func NewGetVaccinesUseCase(repo repositories.VaccineRepository) GetVaccinesUseCase {
+	return &getVaccinesUseCase{
+		vaccineRepo: repo,
+	}
+}
+
+func (uc *getVaccinesUseCase) Execute(ctx context.Context) (*GetVaccinesOutput, error) {
+	vaccines, err := uc.vaccineRepo.ListVaccines(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetVaccinesOutput{
+		Vaccines: make([]VaccineOutput, len(vaccines)),
+	}
+	for i, vaccine := range vaccines {
+		output.Vaccines[i] = VaccineOutput{
+			Product:   vaccine.Vaccine.Product,
+			Company:   vaccine.Vaccine.Company,
+			Vaccine:   vaccine.Vaccine.Vaccine,
+			Platform:  vaccine.Vaccine.Platform,
+			Doses:     vaccine.Vaccine.Doses,
+			WHOEUL:    vaccine.Vaccine.WHOEUL,
+			Countries: vaccine.Countries,
+		}
+	}
+
+	return output, nil
+}
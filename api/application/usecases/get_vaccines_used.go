@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/thalesmacedo1/covid-api/domain/entities"
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
@@ -17,8 +18,9 @@ type GetVaccinesUsedInput struct {
 
 type GetVaccinesUsedOutput struct {
 	Vaccine           entities.Vaccine `json:"vaccine"`
-	StartDate         string           `json:"start_date"`
-	AuthorizationDate string           `json:"authorization_date,omitempty"`
+	StartDate         *string          `json:"start_date"`
+	AuthorizationDate *string          `json:"authorization_date"`
+	EndDate           *string          `json:"end_date"`
 }
 
 type getVaccinesUsedUseCase struct {
@@ -37,6 +39,17 @@ func (uc *getVaccinesUsedUseCase) Execute(ctx context.Context, input GetVaccines
 		return nil, err
 	}
 
+	return vaccinesUsedOutput(vaccinesUsed), nil
+}
+
+// vaccinesUsedOutput converts the vaccines a country used, keeping the first
+// entry of each vaccine.
+func vaccinesUsedOutput(vaccinesUsed []struct {
+	Vaccine           entities.Vaccine
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
+}) []GetVaccinesUsedOutput {
 	uniqueVaccines := make(map[string]bool)
 	var output []GetVaccinesUsedOutput
 
@@ -51,16 +64,23 @@ func (uc *getVaccinesUsedUseCase) Execute(ctx context.Context, input GetVaccines
 		uniqueVaccines[vaccineName] = true
 
 		vaccineOutput := GetVaccinesUsedOutput{
-			Vaccine:   vu.Vaccine,
-			StartDate: vu.StartDate.Format("2006-01-02"),
-		}
-
-		if !vu.AuthorizationDate.IsZero() {
-			vaccineOutput.AuthorizationDate = vu.AuthorizationDate.Format("2006-01-02")
+			Vaccine:           vu.Vaccine,
+			StartDate:         formatDate(vu.StartDate),
+			AuthorizationDate: formatDate(vu.AuthorizationDate),
+			EndDate:           formatDate(vu.EndDate),
 		}
 
 		output = append(output, vaccineOutput)
 	}
 
-	return output, nil
+	return output
+}
+
+// formatDate formats t as YYYY-MM-DD, keeping a missing date as nil.
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02")
+	return &formatted
 }
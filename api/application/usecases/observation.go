@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"time"
+)
+
+// Observation tells which report answered a dated query: the date it was
+// reported on and how many days before the requested date that was.
+type Observation struct {
+	ObservedOn    string
+	StalenessDays int
+}
+
+func newObservation(requested, observed time.Time) Observation {
+	return Observation{
+		ObservedOn:    observed.Format("2006-01-02"),
+		StalenessDays: int(requested.Sub(observed).Hours() / 24),
+	}
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/thalesmacedo1/covid-api/application/usecases"
 	"github.com/thalesmacedo1/covid-api/config"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/memory"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	cacherepos "github.com/thalesmacedo1/covid-api/infrastructure/cache/repositories"
 	"github.com/thalesmacedo1/covid-api/infrastructure/database/neo4j/repositories"
 	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
 	"github.com/thalesmacedo1/covid-api/interfaces/api/handlers"
 	"github.com/thalesmacedo1/covid-api/interfaces/routers"
+	"github.com/thalesmacedo1/covid-shared/events"
+	"github.com/thalesmacedo1/covid-shared/migrations"
 
 	"github.com/thalesmacedo1/covid-api/infrastructure/database/neo4j"
 )
@@ -29,33 +35,114 @@ func main() {
 	}
 	defer neo4jClient.Close()
 
+	// Aplica as migrações de schema pendentes
+	runner, err := migrations.NewRunner(neo4jClient.Driver)
+	if err != nil {
+		logr.Fatalf("Failed to load schema migrations: %v", err)
+	}
+	migrationResult, err := runner.Run(context.Background())
+	if err != nil {
+		logr.Fatalf("Failed to apply schema migrations: %v", err)
+	}
+	for _, migration := range migrationResult.Applied {
+		logr.Infof("Applied schema migration %04d_%s (%s edition)", migration.Version, migration.Name, migrationResult.Edition)
+	}
+
+	// Na edição Community as restrições de existência são verificadas pela aplicação
+	if migrationResult.Edition == migrations.Community {
+		violations, err := runner.CheckExistence(context.Background())
+		if err != nil {
+			logr.Warnf("Failed to check required properties: %v", err)
+		}
+		for _, violation := range violations {
+			logr.Warnf("Schema violation: %v", violation)
+		}
+	}
+
 	// Inicializa os repositórios
 	countryRepo := repositories.NewNeo4jCountryRepository(neo4jClient.Driver)
 	vaccineRepo := repositories.NewNeo4jVaccineRepository(neo4jClient.Driver)
 	covidStatsRepo := repositories.NewNeo4jCovidStatsRepository(neo4jClient.Driver)
 	vaccinationStatsRepo := repositories.NewNeo4jVaccinationStatsRepository(neo4jClient.Driver)
+	rankingRepo := repositories.NewNeo4jRankingRepository(neo4jClient.Driver)
+	regionRepo := repositories.NewNeo4jRegionRepository(neo4jClient.Driver)
+	companyRepo := repositories.NewNeo4jCompanyRepository(neo4jClient.Driver)
+
+	// Inicializa o cache; enquanto o Redis não responde as leituras seguem sem ele e voltam a usá-lo quando ele responde
+	if config.Settings.CacheEnabled {
+		// No modo memory o Redis, quando configurado, só recebe os eventos do ETL
+		var redisClient *redis.RedisClient
+		var cache redis.Cache
+		if config.Settings.CacheBackend.UsesRedis() || config.Settings.RedisHost != "" {
+			redisClient = redis.NewRedisClient(config.Settings.RedisHost, config.Settings.RedisPassword, config.Settings.RedisDB)
+			defer redisClient.Close()
+			if err := redisClient.Ping(context.Background()); err != nil {
+				logr.Warnf("Redis is not available yet, retrying while requests are served without it: %v", err)
+			}
+			if config.Settings.CacheBackend.UsesRedis() {
+				cache = redis.NewRedisCache(redisClient)
+			}
+		}
 
-	// // Inicializa o cliente Redis
-	// redisClient, err := redis.NewRedisClient(config.Settings.RedisHost, config.Settings.RedisPassword, config.Settings.RedisDB)
-	// if err != nil {
-	// 	logr.Fatalf("Failed to initialize Redis client: %v", err)
-	// }
-	// defer redisClient.Close()
+		switch config.Settings.CacheBackend {
+		case config.CacheBackendMemory:
+			cache = memory.NewMemoryCache(config.Settings.CacheMaxEntries)
+		case config.CacheBackendTiered:
+			cache = memory.NewTieredCache(memory.NewMemoryCache(config.Settings.CacheMaxEntries), cache, config.Settings.CacheL1TTL)
+		}
+
+		logr.Infof("Caching queries in the %s backend", config.Settings.CacheBackend)
+		ttls := config.Settings.CacheTTL
+		covidStatsRepo = cacherepos.NewCachedCovidStatsRepository(covidStatsRepo, cache, ttls, logr)
+		vaccinationStatsRepo = cacherepos.NewCachedVaccinationStatsRepository(vaccinationStatsRepo, cache, ttls, logr)
+		vaccineRepo = cacherepos.NewCachedVaccineRepository(vaccineRepo, cache, ttls, logr)
+		rankingRepo = cacherepos.NewCachedRankingRepository(rankingRepo, cache, ttls, logr)
+		companyRepo = cacherepos.NewCachedCompanyRepository(companyRepo, cache, ttls, logr)
+
+		// Descarta os resultados alterados por cada importação do ETL assim que ela termina
+		if redisClient == nil {
+			logr.Warn("Cached results are only refreshed when they expire, Redis is not configured for import events")
+		} else {
+			go cacherepos.NewInvalidator(cache, logr).Subscribe(context.Background(), func(ctx context.Context) (<-chan string, error) {
+				return redisClient.Subscribe(ctx, events.ImportsChannel)
+			})
+		}
+	}
 
 	// Inicializa os use cases
+	getCountriesUC := usecases.NewGetCountriesUseCase(countryRepo)
 	getCovidTotalsUC := usecases.NewGetCovidTotalsUseCase(covidStatsRepo)
 	getVaccinatedPeopleUC := usecases.NewGetVaccinatedPeopleUseCase(vaccinationStatsRepo)
+	getVaccinesUC := usecases.NewGetVaccinesUseCase(vaccineRepo)
+	getVaccineCountriesUC := usecases.NewGetVaccineCountriesUseCase(vaccineRepo)
+	getVaccineAdoptionUC := usecases.NewGetVaccineAdoptionUseCase(vaccineRepo)
 	getVaccinesUsedUC := usecases.NewGetVaccinesUsedUseCase(vaccineRepo)
-	getCountryWithMostCasesUC := usecases.NewGetCountryWithMostCasesUseCase(covidStatsRepo, countryRepo)
-	getMostUsedVaccineUC := usecases.NewGetMostUsedVaccineUseCase(vaccineRepo)
+	getRankingUC := usecases.NewGetRankingUseCase(rankingRepo)
+	getCountryWithMostCasesUC := usecases.NewGetCountryWithMostCasesUseCase(getRankingUC)
+	getRegionVaccineRankingUC := usecases.NewGetRegionVaccineRankingUseCase(vaccineRepo)
+	getMostUsedVaccineUC := usecases.NewGetMostUsedVaccineUseCase(getRegionVaccineRankingUC)
+	getCovidSeriesUC := usecases.NewGetCovidSeriesUseCase(covidStatsRepo)
+	getRegionsUC := usecases.NewGetRegionsUseCase(regionRepo)
+	getRegionCountriesUC := usecases.NewGetRegionCountriesUseCase(regionRepo)
+	getRegionCovidUC := usecases.NewGetRegionCovidUseCase(regionRepo)
+	getCountryVaccinePlatformsUC := usecases.NewGetCountryVaccinePlatformsUseCase(vaccineRepo)
+	getRegionVaccinePlatformsUC := usecases.NewGetRegionVaccinePlatformsUseCase(vaccineRepo)
+	getCompaniesUC := usecases.NewGetCompaniesUseCase(companyRepo)
+	getRegionCompaniesUC := usecases.NewGetRegionCompaniesUseCase(companyRepo)
+	getCountryProfileUC := usecases.NewGetCountryProfileUseCase(countryRepo, regionRepo, covidStatsRepo, vaccinationStatsRepo, vaccineRepo, config.Settings.ProfileTimeout)
 
 	// Inicializa os handlers
-	covidHandler := handlers.NewCovidHandler(getCovidTotalsUC, getCountryWithMostCasesUC, logr)
+	countryHandler := handlers.NewCountryHandler(getCountriesUC, getCountryProfileUC, logr)
+	covidHandler := handlers.NewCovidHandler(getCovidTotalsUC, getCountryWithMostCasesUC, getCovidSeriesUC, logr)
 	vaccinationHandler := handlers.NewVaccinationHandler(getVaccinatedPeopleUC, logr)
-	vaccineHandler := handlers.NewVaccineHandler(getVaccinesUsedUC, getMostUsedVaccineUC, logr)
+	vaccineHandler := handlers.NewVaccineHandler(getVaccinesUC, getVaccineCountriesUC, getVaccineAdoptionUC, getVaccinesUsedUC, getMostUsedVaccineUC, getRegionVaccineRankingUC, logr)
+	rankingHandler := handlers.NewRankingHandler(getRankingUC, logr)
+	regionHandler := handlers.NewRegionHandler(getRegionsUC, getRegionCountriesUC, getRegionCovidUC, logr)
+	companyHandler := handlers.NewCompanyHandler(getCompaniesUC, getRegionCompaniesUC, logr)
+	platformHandler := handlers.NewPlatformHandler(getCountryVaccinePlatformsUC, getRegionVaccinePlatformsUC, logr)
 
 	// Configura o roteador usando Gin
-	router := routers.Router(covidHandler, vaccinationHandler, vaccineHandler, logr)
+	router := routers.Router(countryHandler, covidHandler, vaccinationHandler, vaccineHandler, rankingHandler, regionHandler, companyHandler, platformHandler, logr)
 
 	// Inicia o servidor HTTP
 	logr.Info("Starting server on :8080")
@@ -5,6 +5,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	cacherepos "github.com/thalesmacedo1/covid-api/infrastructure/cache/repositories"
 
 	"github.com/joho/godotenv"
 )
@@ -20,6 +23,33 @@ type Config struct {
 	RedisPort     string
 	RedisPassword string
 	RedisDB       int
+
+	// Cache Configuration
+	CacheEnabled    bool
+	CacheBackend    CacheBackend
+	CacheTTL        cacherepos.TTLs
+	CacheL1TTL      time.Duration
+	CacheMaxEntries int
+
+	// Tempo máximo para montar o perfil de um país
+	ProfileTimeout time.Duration
+}
+
+// CacheBackend is where cached queries are kept.
+type CacheBackend string
+
+const (
+	// CacheBackendRedis shares the cache between every API process
+	CacheBackendRedis CacheBackend = "redis"
+	// CacheBackendMemory keeps it in each process, without needing Redis
+	CacheBackendMemory CacheBackend = "memory"
+	// CacheBackendTiered keeps recent entries in memory in front of Redis
+	CacheBackendTiered CacheBackend = "tiered"
+)
+
+// UsesRedis reports whether the backend needs a Redis server.
+func (b CacheBackend) UsesRedis() bool {
+	return b == CacheBackendRedis || b == CacheBackendTiered
 }
 
 var Settings Config
@@ -45,11 +75,6 @@ func LoadConfig(envFile string) error {
 	Settings.RedisPassword = strings.TrimSpace(os.Getenv("REDIS_PASSWORD"))
 	redisDBStr := strings.TrimSpace(os.Getenv("REDIS_DB"))
 
-	// Validar configurações do Redis
-	if Settings.RedisHost == "" || Settings.RedisPort == "" {
-		log.Fatal("Missing required Redis environment variables (REDIS_HOST, REDIS_PORT)")
-	}
-
 	// Converter RedisDB para int
 	if redisDBStr == "" {
 		Settings.RedisDB = 0
@@ -61,5 +86,66 @@ func LoadConfig(envFile string) error {
 		Settings.RedisDB = db
 	}
 
+	// Carregar as configurações do cache
+	if cacheEnabledStr := strings.TrimSpace(os.Getenv("CACHE_ENABLED")); cacheEnabledStr != "" {
+		enabled, err := strconv.ParseBool(cacheEnabledStr)
+		if err != nil {
+			log.Fatalf("Invalid cache enabled value: %v", err)
+		}
+		Settings.CacheEnabled = enabled
+	}
+	switch backend := CacheBackend(strings.ToLower(strings.TrimSpace(os.Getenv("CACHE_BACKEND")))); backend {
+	case "":
+		Settings.CacheBackend = CacheBackendRedis
+	case CacheBackendRedis, CacheBackendMemory, CacheBackendTiered:
+		Settings.CacheBackend = backend
+	default:
+		log.Fatalf("Invalid cache backend value: %q", backend)
+	}
+	Settings.CacheTTL = cacherepos.TTLs{
+		CovidTotals:      durationEnv("CACHE_TTL_COVID_TOTALS", time.Hour),
+		CovidSeries:      durationEnv("CACHE_TTL_COVID_SERIES", time.Hour),
+		VaccinatedPeople: durationEnv("CACHE_TTL_VACCINATED_PEOPLE", time.Hour),
+		VaccinesUsed:     durationEnv("CACHE_TTL_VACCINES_USED", 6*time.Hour),
+		MostUsedVaccine:  durationEnv("CACHE_TTL_MOST_USED_VACCINE", 6*time.Hour),
+		Companies:        durationEnv("CACHE_TTL_COMPANIES", 6*time.Hour),
+		Ranking:          durationEnv("CACHE_TTL_RANKING", time.Hour),
+		NotFound:         durationEnv("CACHE_TTL_NOT_FOUND", 5*time.Minute),
+		Stale:            durationEnv("CACHE_TTL_STALE", 10*time.Minute),
+		Lock:             durationEnv("CACHE_LOCK_TTL", 10*time.Second),
+	}
+	Settings.CacheL1TTL = durationEnv("CACHE_L1_TTL", 30*time.Second)
+	Settings.CacheMaxEntries = 10000
+	if maxEntriesStr := strings.TrimSpace(os.Getenv("CACHE_MAX_ENTRIES")); maxEntriesStr != "" {
+		maxEntries, err := strconv.Atoi(maxEntriesStr)
+		if err != nil || maxEntries <= 0 {
+			log.Fatalf("Invalid cache max entries value: %q", maxEntriesStr)
+		}
+		Settings.CacheMaxEntries = maxEntries
+	}
+
+	// Validar configurações do Redis, exigidas apenas quando o cache as usa
+	if Settings.CacheEnabled && Settings.CacheBackend.UsesRedis() && (Settings.RedisHost == "" || Settings.RedisPort == "") {
+		log.Fatal("Missing required Redis environment variables (REDIS_HOST, REDIS_PORT)")
+	}
+
+	// Carregar o tempo máximo do perfil de um país
+	Settings.ProfileTimeout = durationEnv("PROFILE_TIMEOUT", 5*time.Second)
+
 	return nil
 }
+
+// durationEnv reads a positive duration such as 90s or 1h from the
+// environment, returning fallback when the variable is unset.
+func durationEnv(name string, fallback time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Fatalf("Invalid %s value: %q", name, value)
+	}
+	return duration
+}
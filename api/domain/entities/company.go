@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+)
+
+// Company is a vaccine manufacturer.
+type Company struct {
+	Name string
+}
+
+func NewCompany(name string) (*Company, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return nil, domain.InvalidInputf("company name cannot be empty")
+	}
+
+	return &Company{
+		Name: name,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestNewCompany(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErr  bool
+		errMsg   string
+		wantName string
+	}{
+		{
+			name:     "Valid company name",
+			input:    "Pfizer BioNTech",
+			wantErr:  false,
+			wantName: "Pfizer BioNTech",
+		},
+		{
+			name:     "Company name with leading and trailing spaces",
+			input:    " Moderna  ",
+			wantErr:  false,
+			wantName: "Moderna",
+		},
+		{
+			name:     "Company name with special characters",
+			input:    "Beijing Bio-Institute Biological Products (CNBG)",
+			wantErr:  false,
+			wantName: "Beijing Bio-Institute Biological Products (CNBG)",
+		},
+		{
+			name:    "Empty company name",
+			input:   "  ",
+			wantErr: true,
+			errMsg:  "company name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			company, err := NewCompany(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCompany() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.errMsg {
+					t.Errorf("NewCompany() error message = '%v', want '%v'", err.Error(), tt.errMsg)
+				}
+				return
+			}
+			if company.Name != tt.wantName {
+				t.Errorf("NewCompany() Name = %v, want %v", company.Name, tt.wantName)
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package entities
 
 import (
-	"errors"
 	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain"
 )
 
 type Country struct {
-	Code string
-	Name string
+	Code    string
+	Name    string
+	ISO2    string
+	ISO3    string
+	Numeric string
 }
 
 func NewCountry(code, name string) (*Country, error) {
@@ -15,15 +19,15 @@ func NewCountry(code, name string) (*Country, error) {
 	name = strings.TrimSpace(name)
 
 	if code == "" {
-		return nil, errors.New("country code cannot be empty")
+		return nil, domain.InvalidInputf("country code cannot be empty")
 	}
 
 	if len(code) != 2 && len(code) != 3 {
-		return nil, errors.New("country code must be 2 or 3 characters long")
+		return nil, domain.InvalidInputf("country code must be 2 or 3 characters long")
 	}
 
 	if name == "" {
-		return nil, errors.New("country name cannot be empty")
+		return nil, domain.InvalidInputf("country name cannot be empty")
 	}
 
 	return &Country{
@@ -1,8 +1,9 @@
 package entities
 
 import (
-	"errors"
 	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain"
 )
 
 type Region struct {
@@ -13,7 +14,7 @@ func NewRegion(name string) (*Region, error) {
 	name = strings.TrimSpace(name)
 
 	if name == "" {
-		return nil, errors.New("region name cannot be empty")
+		return nil, domain.InvalidInputf("region name cannot be empty")
 	}
 
 	return &Region{
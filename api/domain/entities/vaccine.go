@@ -1,9 +1,17 @@
 package entities
 
+// Vaccine is a vaccine product. Platform, Doses and WHOEUL come from the
+// reference mapping the ETL enriches products with: how the vaccine works
+// (mrna, viral_vector, inactivated, protein_subunit, dna or other), how many
+// doses a full course takes and whether it holds a WHO Emergency Use Listing
+// (listed or not_listed). They are empty or nil when the mapping lacks them.
 type Vaccine struct {
-	Product string
-	Company string
-	Vaccine string
+	Product  string
+	Company  string
+	Vaccine  string
+	Platform string
+	Doses    *int
+	WHOEUL   string
 }
 
 func NewVaccine(
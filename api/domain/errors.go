@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors repositories and use cases return, as opposed to
+// unexpected failures. Test for them with errors.Is.
+var (
+	// ErrNotFound matches the errors returned when the requested data does
+	// not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidInput matches the errors returned for requests that can never
+	// succeed as they are, such as a range ending before it starts.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrUpstreamUnavailable matches the errors returned when a service the
+	// data is read from cannot be reached, so the request may succeed later.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+)
+
+// kindError is an error of one of the kinds above, optionally caused by
+// another error it unwraps to.
+type kindError struct {
+	kind    error
+	message string
+	cause   error
+}
+
+func (e *kindError) Error() string { return e.message }
+
+func (e *kindError) Is(target error) bool { return target == e.kind }
+
+func (e *kindError) Unwrap() error { return e.cause }
+
+// NotFoundf formats an error message that matches ErrNotFound.
+func NotFoundf(format string, args ...interface{}) error {
+	return &kindError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidInputf formats an error message that matches ErrInvalidInput.
+func InvalidInputf(format string, args ...interface{}) error {
+	return &kindError{kind: ErrInvalidInput, message: fmt.Sprintf(format, args...)}
+}
+
+// Unavailable wraps err, a failure to reach service, in an error that
+// matches ErrUpstreamUnavailable.
+func Unavailable(service string, err error) error {
+	return &kindError{kind: ErrUpstreamUnavailable, message: fmt.Sprintf("%s unavailable: %v", service, err), cause: err}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNotFoundf(t *testing.T) {
+	err := NotFoundf("country with code %s not found", "XYZ")
+
+	if err.Error() != "country with code XYZ not found" {
+		t.Errorf("got message %q", err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected the error to match ErrNotFound")
+	}
+	if !errors.Is(fmt.Errorf("loading profile: %w", err), ErrNotFound) {
+		t.Error("expected the wrapped error to match ErrNotFound")
+	}
+	if errors.Is(errors.New("connection refused"), ErrNotFound) {
+		t.Error("expected other errors not to match ErrNotFound")
+	}
+}
+
+func TestInvalidInputf(t *testing.T) {
+	err := InvalidInputf("from %s is after to %s", "2024-02-01", "2024-01-01")
+
+	if err.Error() != "from 2024-02-01 is after to 2024-01-01" {
+		t.Errorf("got message %q", err.Error())
+	}
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Error("expected the error to match ErrInvalidInput")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected the error not to match ErrNotFound")
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := Unavailable("Neo4j", cause)
+
+	if err.Error() != "Neo4j unavailable: connection refused" {
+		t.Errorf("got message %q", err.Error())
+	}
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Error("expected the error to match ErrUpstreamUnavailable")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected the error to unwrap to its cause")
+	}
+}
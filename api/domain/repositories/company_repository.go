@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type CompanyRepository interface {
+	// ListCompanies returns the usage of every manufacturer among all
+	// countries, ordered by adopting countries and then by name.
+	ListCompanies(ctx context.Context) (*valueobjects.CompanyAdoption, error)
+	// GetRegionCompanies returns the usage of the manufacturers whose
+	// vaccines some country of the region uses, among the countries of that
+	// region, ordered like ListCompanies.
+	GetRegionCompanies(ctx context.Context, regionName string) (*valueobjects.CompanyAdoption, error)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type CountryRepository interface {
 	GetCountryByCode(ctx context.Context, code string) (*entities.Country, error)
+	// ListCountries returns the countries matching filter ordered by code.
+	ListCountries(ctx context.Context, filter valueobjects.CountryFilter) ([]valueobjects.CountrySummary, error)
 }
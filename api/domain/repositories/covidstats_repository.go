@@ -8,6 +8,8 @@ import (
 )
 
 type CovidStatsRepository interface {
-	GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time) (*valueobjects.CovidStats, error)
-	GetCountryWithMostCases(ctx context.Context, date time.Time) (string, int, error)
+	GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.CovidStats, error)
+	// GetCovidSeries returns the reports of countryCode between from and to,
+	// inclusive and ordered by date. A zero from or to leaves that end open.
+	GetCovidSeries(ctx context.Context, countryCode string, from, to time.Time) ([]valueobjects.CovidStats, error)
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type RankingRepository interface {
+	// GetRanking returns up to query.Limit countries with a value for the
+	// metric, sorted by it in query.Order and then by country name.
+	GetRanking(ctx context.Context, query valueobjects.RankingQuery) ([]valueobjects.RankingEntry, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+)
+
+type RegionRepository interface {
+	// ListRegions returns every region ordered by name.
+	ListRegions(ctx context.Context) ([]valueobjects.RegionSummary, error)
+	// GetRegionCountries returns the countries of a region ordered by name.
+	GetRegionCountries(ctx context.Context, regionName string) ([]entities.Country, error)
+	// GetCountryRegion returns the region a country belongs to.
+	GetCountryRegion(ctx context.Context, countryCode string) (*entities.Region, error)
+	// GetRegionCovidStats returns every country of a region with its report
+	// on date, or with DateModeAsOf its latest report on or before date.
+	GetRegionCovidStats(ctx context.Context, regionName string, date time.Time, mode valueobjects.DateMode) ([]valueobjects.CountryCovidStats, error)
+}
@@ -8,5 +8,5 @@ import (
 )
 
 type VaccinationStatsRepository interface {
-	GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time) (*valueobjects.VaccinationStats, error)
+	GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.VaccinationStats, error)
 }
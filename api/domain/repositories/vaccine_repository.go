@@ -5,14 +5,26 @@ import (
 	"time"
 
 	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 )
 
 type VaccineRepository interface {
 	GetVaccinesUsed(ctx context.Context, countryCode string) ([]struct {
 		Vaccine           entities.Vaccine
-		StartDate         time.Time
-		AuthorizationDate time.Time
+		StartDate         *time.Time
+		AuthorizationDate *time.Time
+		EndDate           *time.Time
 	}, error)
 
-	GetMostUsedVaccine(ctx context.Context, regionName string) (*entities.Vaccine, int, error)
+	// GetRegionVaccineUsage returns every country of the region with the
+	// vaccines it uses and its doses administered, ordered by country code.
+	GetRegionVaccineUsage(ctx context.Context, regionName string) ([]valueobjects.CountryVaccineUsage, error)
+
+	// ListVaccines returns every vaccine with the number of countries that use
+	// it, ordered by product.
+	ListVaccines(ctx context.Context) ([]valueobjects.VaccineSummary, error)
+	// GetVaccineCountries returns a vaccine with the countries that use it,
+	// ordered by country code. A non-empty regionName keeps only the
+	// countries of that region, and is not found when it has none.
+	GetVaccineCountries(ctx context.Context, product string, regionName string) (*valueobjects.VaccineAdoption, error)
 }
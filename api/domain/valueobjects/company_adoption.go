@@ -0,0 +1,21 @@
+package valueobjects
+
+import (
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// CompanyUsage is a manufacturer with the number of vaccines it produces,
+// how many of them are used and by how many countries.
+type CompanyUsage struct {
+	Company      entities.Company
+	Products     int
+	ProductsUsed int
+	Countries    int
+}
+
+// CompanyAdoption is the usage of every manufacturer among a set of member
+// countries.
+type CompanyAdoption struct {
+	MemberCountries int
+	Companies       []CompanyUsage
+}
@@ -0,0 +1,109 @@
+package valueobjects
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// DateCoverage is the range of dates a country has reports for. Both ends
+// are the zero time when it has none.
+type DateCoverage struct {
+	From time.Time
+	To   time.Time
+}
+
+// CountrySummary is a catalogue entry: a country, its region and the dates
+// its COVID-19 and vaccination reports cover.
+type CountrySummary struct {
+	Country     entities.Country
+	Region      string
+	Covid       DateCoverage
+	Vaccination DateCoverage
+}
+
+// CountryFilter narrows the catalogue. An empty Region keeps every region and
+// a nil HasVaccinationData keeps countries with and without vaccination
+// reports.
+type CountryFilter struct {
+	Region             string
+	HasVaccinationData *bool
+}
+
+// CountrySort is the field the country catalogue is sorted by.
+type CountrySort string
+
+const (
+	CountrySortName   CountrySort = "name"
+	CountrySortCode   CountrySort = "code"
+	CountrySortRegion CountrySort = "region"
+)
+
+// ParseCountrySort validates a sort field. An empty value means name.
+func ParseCountrySort(value string) (CountrySort, error) {
+	switch sort := CountrySort(strings.ToLower(strings.TrimSpace(value))); sort {
+	case "":
+		return CountrySortName, nil
+	case CountrySortName, CountrySortCode, CountrySortRegion:
+		return sort, nil
+	default:
+		return "", domain.InvalidInputf("unknown country sort %q", value)
+	}
+}
+
+// NameMatch is how a search term is matched against country names.
+type NameMatch string
+
+const (
+	NameMatchContains NameMatch = "contains"
+	NameMatchFuzzy    NameMatch = "fuzzy"
+)
+
+// ParseNameMatch validates a match mode. An empty value means contains.
+func ParseNameMatch(value string) (NameMatch, error) {
+	switch match := NameMatch(strings.ToLower(strings.TrimSpace(value))); match {
+	case "":
+		return NameMatchContains, nil
+	case NameMatchContains, NameMatchFuzzy:
+		return match, nil
+	default:
+		return "", domain.InvalidInputf("unknown name match %q", value)
+	}
+}
+
+// Cursor marks the last entry of a page by its sort value and its code, which
+// breaks ties between entries with the same sort value.
+type Cursor struct {
+	Value string `json:"v"`
+	Code  string `json:"c"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a token made by Encode. An empty token means the first
+// page and returns nil.
+func ParseCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, domain.InvalidInputf("invalid cursor %q", token)
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Code == "" {
+		return nil, domain.InvalidInputf("invalid cursor %q", token)
+	}
+
+	return &cursor, nil
+}
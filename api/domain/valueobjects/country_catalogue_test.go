@@ -0,0 +1,27 @@
+package valueobjects
+
+import "testing"
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{Value: "côte d'ivoire", Code: "CIV"}
+
+	parsed, err := ParseCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *parsed != cursor {
+		t.Errorf("got %+v, want %+v", *parsed, cursor)
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	if cursor, err := ParseCursor(""); cursor != nil || err != nil {
+		t.Errorf("empty token: got %v, %v, want nil, nil", cursor, err)
+	}
+
+	for _, token := range []string{"not base64!", "bm90IGpzb24", Cursor{Value: "x"}.Encode()} {
+		if _, err := ParseCursor(token); err == nil {
+			t.Errorf("token %q: expected an error", token)
+		}
+	}
+}
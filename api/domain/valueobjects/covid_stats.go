@@ -1,13 +1,21 @@
 package valueobjects
 
+import (
+	"time"
+)
+
+// CovidStats holds a country's COVID-19 counts as reported on ReportedOn. A
+// nil count was not reported by the source, which is not the same as a
+// reported 0.
 type CovidStats struct {
-	CumulativeDeaths int
-	NewDeaths        int
-	CumulativeCases  int
-	NewCases         int
+	ReportedOn       time.Time
+	CumulativeDeaths *int
+	NewDeaths        *int
+	CumulativeCases  *int
+	NewCases         *int
 }
 
-func NewCovidStats(cumulativeDeaths, newDeaths, cumulativeCases, newCases int) CovidStats {
+func NewCovidStats(cumulativeDeaths, newDeaths, cumulativeCases, newCases *int) CovidStats {
 	return CovidStats{
 		CumulativeDeaths: cumulativeDeaths,
 		NewDeaths:        newDeaths,
@@ -1,7 +1,10 @@
 package valueobjects
 
 import (
+	"strings"
 	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
 )
 
 type Date struct {
@@ -11,3 +14,26 @@ type Date struct {
 func NewDate(t time.Time) Date {
 	return Date{Time: t}
 }
+
+// DateMode tells how a requested date is matched against the dates the
+// statistics were reported on.
+type DateMode string
+
+const (
+	// DateModeAsOf matches the latest report on or before the requested date
+	DateModeAsOf DateMode = "as_of"
+	// DateModeExact only matches a report on the requested date itself
+	DateModeExact DateMode = "exact"
+)
+
+// ParseDateMode validates a date mode name. An empty value means as_of.
+func ParseDateMode(value string) (DateMode, error) {
+	switch mode := DateMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return DateModeAsOf, nil
+	case DateModeAsOf, DateModeExact:
+		return mode, nil
+	default:
+		return "", domain.InvalidInputf("unknown date mode %q", value)
+	}
+}
@@ -0,0 +1,31 @@
+package valueobjects
+
+import (
+	"testing"
+)
+
+func TestParseDateMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    DateMode
+		wantErr bool
+	}{
+		{name: "Default mode", input: "", want: DateModeAsOf},
+		{name: "As of", input: "as_of", want: DateModeAsOf},
+		{name: "Exact with spaces and capitals", input: " Exact ", want: DateModeExact},
+		{name: "Unknown mode", input: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDateMode(%q) = %q; want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
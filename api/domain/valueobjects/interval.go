@@ -0,0 +1,46 @@
+package valueobjects
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+)
+
+// Interval is the period a time series is resampled to.
+type Interval string
+
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// ParseInterval validates an interval name. An empty value means day.
+func ParseInterval(value string) (Interval, error) {
+	switch interval := Interval(strings.ToLower(strings.TrimSpace(value))); interval {
+	case "":
+		return IntervalDay, nil
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return interval, nil
+	default:
+		return "", domain.InvalidInputf("unknown interval %q", value)
+	}
+}
+
+// PeriodStart returns the first day of the period t falls in. Weeks start on
+// Monday, as ISO 8601 weeks do.
+func (i Interval) PeriodStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch i {
+	case IntervalWeek:
+		// Sunday is day 0 in Go; count it as the last day of the week
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case IntervalMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
@@ -0,0 +1,48 @@
+package valueobjects
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Interval
+		wantErr bool
+	}{
+		{input: "", want: IntervalDay},
+		{input: "week", want: IntervalWeek},
+		{input: " MONTH ", want: IntervalMonth},
+		{input: "year", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInterval(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseInterval(%q) = %q, %v; want %q, wantErr %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestIntervalPeriodStart(t *testing.T) {
+	// 2023-01-08 is a Sunday
+	sunday := time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval Interval
+		input    time.Time
+		want     time.Time
+	}{
+		{interval: IntervalDay, input: sunday, want: sunday},
+		{interval: IntervalWeek, input: sunday, want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{interval: IntervalWeek, input: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{interval: IntervalMonth, input: sunday, want: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.interval.PeriodStart(tt.input); !got.Equal(tt.want) {
+			t.Errorf("%s.PeriodStart(%v) = %v; want %v", tt.interval, tt.input, got, tt.want)
+		}
+	}
+}
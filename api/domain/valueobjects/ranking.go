@@ -0,0 +1,94 @@
+package valueobjects
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// RankingMetric is a reported figure countries can be ranked by.
+type RankingMetric string
+
+const (
+	MetricCumulativeCases              RankingMetric = "cumulative_cases"
+	MetricCumulativeDeaths             RankingMetric = "cumulative_deaths"
+	MetricNewCases                     RankingMetric = "new_cases"
+	MetricNewDeaths                    RankingMetric = "new_deaths"
+	MetricTotalVaccinations            RankingMetric = "total_vaccinations"
+	MetricTotalVaccinationsPer100      RankingMetric = "total_vaccinations_per100"
+	MetricPersonsVaccinated            RankingMetric = "persons_vaccinated"
+	MetricPersonsVaccinatedPer100      RankingMetric = "persons_vaccinated_per100"
+	MetricPersonsFullyVaccinated       RankingMetric = "persons_fully_vaccinated"
+	MetricPersonsFullyVaccinatedPer100 RankingMetric = "persons_fully_vaccinated_per100"
+	MetricPersonsBooster               RankingMetric = "persons_booster"
+	MetricPersonsBoosterPer100         RankingMetric = "persons_booster_per100"
+)
+
+// RankingMetrics lists every metric, COVID-19 figures first.
+var RankingMetrics = []RankingMetric{
+	MetricCumulativeCases,
+	MetricCumulativeDeaths,
+	MetricNewCases,
+	MetricNewDeaths,
+	MetricTotalVaccinations,
+	MetricTotalVaccinationsPer100,
+	MetricPersonsVaccinated,
+	MetricPersonsVaccinatedPer100,
+	MetricPersonsFullyVaccinated,
+	MetricPersonsFullyVaccinatedPer100,
+	MetricPersonsBooster,
+	MetricPersonsBoosterPer100,
+}
+
+// ParseRankingMetric validates a metric name.
+func ParseRankingMetric(value string) (RankingMetric, error) {
+	metric := RankingMetric(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range RankingMetrics {
+		if metric == known {
+			return metric, nil
+		}
+	}
+	return "", domain.InvalidInputf("unknown ranking metric %q", value)
+}
+
+// SortOrder is the direction a ranking is sorted in.
+type SortOrder string
+
+const (
+	SortDescending SortOrder = "desc"
+	SortAscending  SortOrder = "asc"
+)
+
+// ParseSortOrder validates a sort order. An empty value means desc.
+func ParseSortOrder(value string) (SortOrder, error) {
+	switch order := SortOrder(strings.ToLower(strings.TrimSpace(value))); order {
+	case "":
+		return SortDescending, nil
+	case SortDescending, SortAscending:
+		return order, nil
+	default:
+		return "", domain.InvalidInputf("unknown sort order %q", value)
+	}
+}
+
+// RankingQuery selects the countries of a ranking. An empty Region ranks
+// every country.
+type RankingQuery struct {
+	Metric RankingMetric
+	Date   time.Time
+	Mode   DateMode
+	Order  SortOrder
+	Region string
+	Limit  int
+}
+
+// RankingEntry is the value of a ranking metric for one country, taken from
+// the report of ReportedOn.
+type RankingEntry struct {
+	Country    entities.Country
+	Region     string
+	Value      float64
+	ReportedOn time.Time
+}
@@ -0,0 +1,18 @@
+package valueobjects
+
+import (
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// RegionSummary is a region with the number of countries that belong to it.
+type RegionSummary struct {
+	Region    entities.Region
+	Countries int
+}
+
+// CountryCovidStats pairs a country with one of its COVID-19 reports. Stats
+// is nil when the country has no report matching the query.
+type CountryCovidStats struct {
+	Country entities.Country
+	Stats   *CovidStats
+}
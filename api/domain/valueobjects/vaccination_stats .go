@@ -1,21 +1,29 @@
 package valueobjects
 
+import (
+	"time"
+)
+
+// VaccinationStats holds a country's vaccination figures as reported on
+// ReportedOn. A nil figure was not reported by the source, which is not the
+// same as a reported 0. The per-100 rates keep their decimals.
 type VaccinationStats struct {
-	PersonsBoosterAddDosePer100      int
-	PersonsBoosterAddDose            int
-	PersonsLastDosePer100            int
-	PersonsLastDose                  int
-	PersonsVaccinated1PlusDosePer100 int
-	TotalVaccinationsPer100          int
-	PersonsVaccinated1PlusDose       int
-	TotalVaccinations                int
+	ReportedOn                       time.Time
+	PersonsBoosterAddDosePer100      *float64
+	PersonsBoosterAddDose            *int
+	PersonsLastDosePer100            *float64
+	PersonsLastDose                  *int
+	PersonsVaccinated1PlusDosePer100 *float64
+	TotalVaccinationsPer100          *float64
+	PersonsVaccinated1PlusDose       *int
+	TotalVaccinations                *int
 }
 
 func NewVaccinationStats(
-	personsBoosterAddDosePer100, personsBoosterAddDose,
-	personsLastDosePer100, personsLastDose,
-	personsVaccinated1PlusDosePer100, totalVaccinationsPer100,
-	personsVaccinated1PlusDose, totalVaccinations int,
+	personsBoosterAddDosePer100 *float64, personsBoosterAddDose *int,
+	personsLastDosePer100 *float64, personsLastDose *int,
+	personsVaccinated1PlusDosePer100, totalVaccinationsPer100 *float64,
+	personsVaccinated1PlusDose, totalVaccinations *int,
 ) VaccinationStats {
 	return VaccinationStats{
 		PersonsBoosterAddDosePer100:      personsBoosterAddDosePer100,
@@ -0,0 +1,29 @@
+package valueobjects
+
+import (
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// VaccineSummary is a vaccine with the number of countries that use it.
+type VaccineSummary struct {
+	Vaccine   entities.Vaccine
+	Countries int
+}
+
+// VaccineAdopter is a country that uses a vaccine, with the dates it
+// reported for it. Region is empty when the country belongs to none.
+type VaccineAdopter struct {
+	Country           entities.Country
+	Region            string
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
+}
+
+// VaccineAdoption is a vaccine with the countries that use it.
+type VaccineAdoption struct {
+	Vaccine   entities.Vaccine
+	Countries []VaccineAdopter
+}
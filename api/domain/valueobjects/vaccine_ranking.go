@@ -0,0 +1,44 @@
+package valueobjects
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+)
+
+// VaccineWeight is what a vaccine's usage in a region is measured by.
+type VaccineWeight string
+
+const (
+	// WeightCountries counts the countries that adopted the vaccine
+	WeightCountries VaccineWeight = "countries"
+	// WeightEstimatedDoses estimates the doses of the vaccine administered.
+	// The data has no doses per vaccine, so each country's reported doses are
+	// assumed split evenly between the vaccines it uses
+	WeightEstimatedDoses VaccineWeight = "estimated_doses"
+)
+
+// ParseVaccineWeight validates a vaccine weight. An empty value means
+// countries.
+func ParseVaccineWeight(value string) (VaccineWeight, error) {
+	switch weight := VaccineWeight(strings.ToLower(strings.TrimSpace(value))); weight {
+	case "":
+		return WeightCountries, nil
+	case WeightCountries, WeightEstimatedDoses:
+		return weight, nil
+	default:
+		return "", domain.InvalidInputf("unknown vaccine weight %q", value)
+	}
+}
+
+// CountryVaccineUsage is a country with the vaccines it uses and its latest
+// reported total of doses administered. Doses and DosesReportedOn are nil
+// when the country never reported it.
+type CountryVaccineUsage struct {
+	Country         entities.Country
+	Vaccines        []entities.Vaccine
+	Doses           *int
+	DosesReportedOn *time.Time
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+)
+
+var _ redis.Cache = (*MemoryCache)(nil)
+
+// MemoryCache is an in-process Cache holding up to a fixed number of entries.
+// When full it evicts the least recently used entry, and entries set with an
+// expiration are dropped once it passes, like in Redis.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (mc *MemoryCache) SetCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.set(key, value, expiration)
+	return nil
+}
+
+func (mc *MemoryCache) GetCache(ctx context.Context, key string) (string, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry := mc.get(key)
+	if entry == nil {
+		return "", fmt.Errorf("cache key %s does not exist: %w", key, redis.ErrCacheMiss)
+	}
+	return entry.value, nil
+}
+
+func (mc *MemoryCache) DeleteCache(ctx context.Context, key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if element, ok := mc.entries[key]; ok {
+		mc.remove(element)
+	}
+	return nil
+}
+
+func (mc *MemoryCache) SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.get(key) != nil {
+		return false, nil
+	}
+	mc.set(key, value, expiration)
+	return true, nil
+}
+
+func (mc *MemoryCache) DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry := mc.get(key)
+	if entry == nil || entry.value != value {
+		return false, nil
+	}
+	mc.remove(mc.entries[key])
+	return true, nil
+}
+
+func (mc *MemoryCache) ScanCache(ctx context.Context, prefix string) ([]string, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	now := time.Now()
+	var keys []string
+	for key, element := range mc.entries {
+		if strings.HasPrefix(key, prefix) && !element.Value.(*memoryEntry).expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (mc *MemoryCache) ExistsCache(ctx context.Context, key string) (bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.get(key) != nil, nil
+}
+
+func (mc *MemoryCache) SetCacheJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value for key %s: %w", key, err)
+	}
+	return mc.SetCache(ctx, key, data, expiration)
+}
+
+func (mc *MemoryCache) GetCacheJSON(ctx context.Context, key string, dest interface{}) error {
+	data, err := mc.GetCache(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal cache data for key %s: %w", key, err)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, including expired ones not yet
+// dropped.
+func (mc *MemoryCache) Len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.order.Len()
+}
+
+// get returns the live entry of key, marking it as the most recently used.
+func (mc *MemoryCache) get(key string) *memoryEntry {
+	element, ok := mc.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		mc.remove(element)
+		return nil
+	}
+
+	mc.order.MoveToFront(element)
+	return entry
+}
+
+func (mc *MemoryCache) set(key string, value interface{}, expiration time.Duration) {
+	entry := &memoryEntry{key: key, value: stringValue(value)}
+	if expiration > 0 {
+		entry.expiresAt = time.Now().Add(expiration)
+	}
+
+	if element, ok := mc.entries[key]; ok {
+		element.Value = entry
+		mc.order.MoveToFront(element)
+		return
+	}
+
+	mc.entries[key] = mc.order.PushFront(entry)
+	for mc.maxEntries > 0 && mc.order.Len() > mc.maxEntries {
+		mc.remove(mc.order.Back())
+	}
+}
+
+func (mc *MemoryCache) remove(element *list.Element) {
+	mc.order.Remove(element)
+	delete(mc.entries, element.Value.(*memoryEntry).key)
+}
+
+// stringValue converts a value the way Redis stores it, so GetCache returns
+// the same string from either backend.
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
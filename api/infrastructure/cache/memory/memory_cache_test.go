@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+)
+
+func TestMemoryCacheSetAndGet(t *testing.T) {
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "string", "value", 0))
+	assert.NoError(t, cache.SetCache(ctx, "bytes", []byte("value"), 0))
+	assert.NoError(t, cache.SetCache(ctx, "number", 42, 0))
+
+	for key, want := range map[string]string{"string": "value", "bytes": "value", "number": "42"} {
+		value, err := cache.GetCache(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, want, value)
+	}
+
+	_, err := cache.GetCache(ctx, "missing")
+	assert.ErrorIs(t, err, redis.ErrCacheMiss)
+	assert.Contains(t, err.Error(), "cache key missing does not exist")
+}
+
+func TestMemoryCacheExpiration(t *testing.T) {
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "short", "value", 20*time.Millisecond))
+	assert.NoError(t, cache.SetCache(ctx, "forever", "value", 0))
+
+	exists, _ := cache.ExistsCache(ctx, "short")
+	assert.True(t, exists)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err := cache.GetCache(ctx, "short")
+	assert.ErrorIs(t, err, redis.ErrCacheMiss)
+	exists, _ = cache.ExistsCache(ctx, "forever")
+	assert.True(t, exists)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "a", "1", 0))
+	assert.NoError(t, cache.SetCache(ctx, "b", "2", 0))
+	_, err := cache.GetCache(ctx, "a")
+	assert.NoError(t, err)
+	assert.NoError(t, cache.SetCache(ctx, "c", "3", 0))
+
+	_, err = cache.GetCache(ctx, "b")
+	assert.ErrorIs(t, err, redis.ErrCacheMiss)
+	for _, key := range []string{"a", "c"} {
+		_, err := cache.GetCache(ctx, key)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestMemoryCacheLocks(t *testing.T) {
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+
+	set, err := cache.SetCacheNX(ctx, "lock", "token", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, set)
+
+	set, err = cache.SetCacheNX(ctx, "lock", "other", time.Second)
+	assert.NoError(t, err)
+	assert.False(t, set)
+
+	deleted, err := cache.DeleteCacheIfEquals(ctx, "lock", "other")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+
+	deleted, err = cache.DeleteCacheIfEquals(ctx, "lock", "token")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	exists, _ := cache.ExistsCache(ctx, "lock")
+	assert.False(t, exists)
+}
+
+func TestMemoryCacheScan(t *testing.T) {
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "covid:BRA", "1", 0))
+	assert.NoError(t, cache.SetCache(ctx, "covid:ARG", "2", 0))
+	assert.NoError(t, cache.SetCache(ctx, "covid:CHL", "3", time.Millisecond))
+	assert.NoError(t, cache.SetCache(ctx, "vaccines:BRA", "4", 0))
+
+	time.Sleep(5 * time.Millisecond)
+
+	keys, err := cache.ScanCache(ctx, "covid:")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"covid:BRA", "covid:ARG"}, keys)
+}
+
+func TestMemoryCacheJSON(t *testing.T) {
+	cache := NewMemoryCache(10)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCacheJSON(ctx, "key", map[string]int{"cases": 10}, time.Minute))
+
+	var result map[string]int
+	assert.NoError(t, cache.GetCacheJSON(ctx, "key", &result))
+	assert.Equal(t, map[string]int{"cases": 10}, result)
+
+	assert.NoError(t, cache.SetCache(ctx, "invalid", "{", 0))
+	err := cache.GetCacheJSON(ctx, "invalid", &result)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unmarshal cache data for key")
+
+	assert.NoError(t, cache.DeleteCache(ctx, "key"))
+	assert.ErrorIs(t, cache.GetCacheJSON(ctx, "key", &result), redis.ErrCacheMiss)
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+)
+
+var _ redis.Cache = (*TieredCache)(nil)
+
+// TieredCache puts a MemoryCache in front of a shared cache. Reads are
+// answered from memory for up to l1TTL and go to the shared cache after
+// that, so an entry changed by another process is seen within l1TTL. Locks
+// are only taken in the shared cache, where every process sees them.
+type TieredCache struct {
+	l1    *MemoryCache
+	l2    redis.Cache
+	l1TTL time.Duration
+}
+
+func NewTieredCache(l1 *MemoryCache, l2 redis.Cache, l1TTL time.Duration) *TieredCache {
+	return &TieredCache{
+		l1:    l1,
+		l2:    l2,
+		l1TTL: l1TTL,
+	}
+}
+
+func (tc *TieredCache) SetCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if err := tc.l2.SetCache(ctx, key, value, expiration); err != nil {
+		tc.l1.DeleteCache(ctx, key)
+		return err
+	}
+	return tc.l1.SetCache(ctx, key, value, tc.l1Expiration(expiration))
+}
+
+func (tc *TieredCache) GetCache(ctx context.Context, key string) (string, error) {
+	if value, err := tc.l1.GetCache(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := tc.l2.GetCache(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	// The remaining TTL of the shared entry is unknown, so it is kept for l1TTL
+	tc.l1.SetCache(ctx, key, value, tc.l1TTL)
+	return value, nil
+}
+
+func (tc *TieredCache) DeleteCache(ctx context.Context, key string) error {
+	tc.l1.DeleteCache(ctx, key)
+	return tc.l2.DeleteCache(ctx, key)
+}
+
+func (tc *TieredCache) SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return tc.l2.SetCacheNX(ctx, key, value, expiration)
+}
+
+func (tc *TieredCache) DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error) {
+	return tc.l2.DeleteCacheIfEquals(ctx, key, value)
+}
+
+// ScanCache returns the keys of both caches, since memory may still hold
+// entries the shared cache already dropped.
+func (tc *TieredCache) ScanCache(ctx context.Context, prefix string) ([]string, error) {
+	keys, err := tc.l2.ScanCache(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		seen[key] = struct{}{}
+	}
+	l1Keys, _ := tc.l1.ScanCache(ctx, prefix)
+	for _, key := range l1Keys {
+		if _, ok := seen[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (tc *TieredCache) ExistsCache(ctx context.Context, key string) (bool, error) {
+	if exists, _ := tc.l1.ExistsCache(ctx, key); exists {
+		return true, nil
+	}
+	return tc.l2.ExistsCache(ctx, key)
+}
+
+func (tc *TieredCache) SetCacheJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value for key %s: %w", key, err)
+	}
+	return tc.SetCache(ctx, key, data, expiration)
+}
+
+func (tc *TieredCache) GetCacheJSON(ctx context.Context, key string, dest interface{}) error {
+	data, err := tc.GetCache(ctx, key)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal cache data for key %s: %w", key, err)
+	}
+	return nil
+}
+
+// l1Expiration caps expiration at l1TTL, treating no expiration as longer
+// than any.
+func (tc *TieredCache) l1Expiration(expiration time.Duration) time.Duration {
+	if expiration <= 0 || expiration > tc.l1TTL {
+		return tc.l1TTL
+	}
+	return expiration
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+)
+
+func TestTieredCacheReadsFromMemoryFirst(t *testing.T) {
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	cache := NewTieredCache(l1, l2, time.Minute)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "key", "v1", time.Hour))
+
+	// Another process changes the shared entry; this one keeps its copy
+	assert.NoError(t, l2.SetCache(ctx, "key", "v2", time.Hour))
+	value, err := cache.GetCache(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+
+	assert.NoError(t, l1.DeleteCache(ctx, "key"))
+	value, err = cache.GetCache(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	value, err = l1.GetCache(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+}
+
+func TestTieredCacheCapsMemoryTTL(t *testing.T) {
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	cache := NewTieredCache(l1, l2, 20*time.Millisecond)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "key", "v1", time.Hour))
+	assert.NoError(t, l2.SetCache(ctx, "key", "v2", time.Hour))
+
+	time.Sleep(30 * time.Millisecond)
+
+	value, err := cache.GetCache(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+}
+
+func TestTieredCacheDelete(t *testing.T) {
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	cache := NewTieredCache(l1, l2, time.Minute)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCacheJSON(ctx, "key", []int{1}, time.Hour))
+	assert.NoError(t, cache.DeleteCache(ctx, "key"))
+
+	var result []int
+	assert.ErrorIs(t, cache.GetCacheJSON(ctx, "key", &result), redis.ErrCacheMiss)
+	exists, _ := l2.ExistsCache(ctx, "key")
+	assert.False(t, exists)
+}
+
+func TestTieredCacheLocksInSharedCache(t *testing.T) {
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	cache := NewTieredCache(l1, l2, time.Minute)
+	ctx := context.Background()
+
+	set, err := cache.SetCacheNX(ctx, "lock", "token", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	assert.Equal(t, 0, l1.Len())
+
+	deleted, err := cache.DeleteCacheIfEquals(ctx, "lock", "token")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Equal(t, 0, l2.Len())
+}
+
+func TestTieredCacheScansBothCaches(t *testing.T) {
+	l1, l2 := NewMemoryCache(10), NewMemoryCache(10)
+	cache := NewTieredCache(l1, l2, time.Minute)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.SetCache(ctx, "covid:BRA", "1", time.Hour))
+	assert.NoError(t, l1.SetCache(ctx, "covid:ARG", "2", time.Hour))
+	assert.NoError(t, l2.SetCache(ctx, "covid:CHL", "3", time.Hour))
+
+	keys, err := cache.ScanCache(ctx, "covid:")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"covid:BRA", "covid:ARG", "covid:CHL"}, keys)
+}
@@ -2,19 +2,29 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 )
 
+// ErrCacheMiss is wrapped by GetCache and GetCacheJSON when the key does not
+// exist.
+var ErrCacheMiss = errors.New("cache miss")
+
 type Cache interface {
 	SetCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	GetCache(ctx context.Context, key string) (string, error)
 	DeleteCache(ctx context.Context, key string) error
+	SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error)
+	ScanCache(ctx context.Context, prefix string) ([]string, error)
 	ExistsCache(ctx context.Context, key string) (bool, error)
 	SetCacheJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	GetCacheJSON(ctx context.Context, key string, dest interface{}) error
 }
 
+var _ Cache = (*RedisCache)(nil)
+
 type RedisCache struct {
 	client *RedisClient
 }
@@ -25,27 +35,39 @@ func NewRedisCache(client *RedisClient) *RedisCache {
 	}
 }
 
-func (rc *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+func (rc *RedisCache) SetCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	return rc.client.SetCache(ctx, key, value, expiration)
 }
 
-func (rc *RedisCache) Get(ctx context.Context, key string) (string, error) {
+func (rc *RedisCache) GetCache(ctx context.Context, key string) (string, error) {
 	return rc.client.GetCache(ctx, key)
 }
 
-func (rc *RedisCache) Delete(ctx context.Context, key string) error {
+func (rc *RedisCache) DeleteCache(ctx context.Context, key string) error {
 	return rc.client.DeleteCache(ctx, key)
 }
 
-func (rc *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+func (rc *RedisCache) SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return rc.client.SetCacheNX(ctx, key, value, expiration)
+}
+
+func (rc *RedisCache) DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error) {
+	return rc.client.DeleteCacheIfEquals(ctx, key, value)
+}
+
+func (rc *RedisCache) ScanCache(ctx context.Context, prefix string) ([]string, error) {
+	return rc.client.ScanCache(ctx, prefix)
+}
+
+func (rc *RedisCache) ExistsCache(ctx context.Context, key string) (bool, error) {
 	return rc.client.ExistsCache(ctx, key)
 }
 
-func (rc *RedisCache) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+func (rc *RedisCache) SetCacheJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	return rc.client.SetCacheJSON(ctx, key, value, expiration)
 }
 
-func (rc *RedisCache) GetJSON(ctx context.Context, key string, dest interface{}) error {
+func (rc *RedisCache) GetCacheJSON(ctx context.Context, key string, dest interface{}) error {
 	return rc.client.GetCacheJSON(ctx, key, dest)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -13,7 +14,10 @@ type RedisClient struct {
 	client *redis.Client
 }
 
-func NewRedisClient(addr, password string, db int) (*RedisClient, error) {
+// NewRedisClient creates a client for the server at addr. It does not
+// connect until the first command, so the API starts while Redis is down;
+// call Ping to check that it answers.
+func NewRedisClient(addr, password string, db int) *RedisClient {
 	options := &redis.Options{
 		Addr:         addr,
 		Password:     password,
@@ -23,19 +27,20 @@ func NewRedisClient(addr, password string, db int) (*RedisClient, error) {
 		WriteTimeout: 3 * time.Second,
 	}
 
-	client := redis.NewClient(options)
+	return &RedisClient{
+		client: redis.NewClient(options),
+	}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Ping checks that the server answers within 5 seconds.
+func (r *RedisClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	err := client.Ping(ctx).Err()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to connect to Redis: %w", err)
 	}
-
-	return &RedisClient{
-		client: client,
-	}, nil
+	return nil
 }
 
 func (r *RedisClient) Close() error {
@@ -53,7 +58,7 @@ func (r *RedisClient) SetCache(ctx context.Context, key string, value interface{
 func (r *RedisClient) GetCache(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return "", fmt.Errorf("cache key %s does not exist", key)
+		return "", fmt.Errorf("cache key %s does not exist: %w", key, ErrCacheMiss)
 	} else if err != nil {
 		return "", fmt.Errorf("failed to get cache for key %s: %w", key, err)
 	}
@@ -68,6 +73,91 @@ func (r *RedisClient) DeleteCache(ctx context.Context, key string) error {
 	return nil
 }
 
+// SetCacheNX sets key only when it does not exist yet, reporting whether it
+// did.
+func (r *RedisClient) SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	set, err := r.client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache for key %s: %w", key, err)
+	}
+	return set, nil
+}
+
+// deleteIfEquals deletes KEYS[1] only while it still holds ARGV[1], so a
+// caller whose entry expired cannot delete one set after it.
+var deleteIfEquals = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// DeleteCacheIfEquals deletes key only when it holds value, reporting whether
+// it did.
+func (r *RedisClient) DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error) {
+	deleted, err := deleteIfEquals.Run(ctx, r.client, []string{key}, value).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete cache for key %s: %w", key, err)
+	}
+	return deleted > 0, nil
+}
+
+// scanCount is how many keys ScanCache asks Redis to look at per call.
+const scanCount = 1000
+
+// ScanCache returns the keys starting with prefix. It walks the keyspace with
+// SCAN, so Redis keeps serving other clients while it runs.
+func (r *RedisClient) ScanCache(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	iter := r.client.Scan(ctx, 0, globEscaper.Replace(prefix)+"*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan cache keys with prefix %s: %w", prefix, err)
+	}
+	return keys, nil
+}
+
+// globEscaper escapes the characters SCAN patterns give a meaning to.
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// Subscribe delivers the payloads of the messages published on channel until
+// ctx is done. The client reconnects and subscribes again when the
+// connection drops, and messages published meanwhile are lost.
+func (r *RedisClient) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	pubsub := r.client.Subscribe(ctx, channel)
+	// Wait for the confirmation, so a failure to subscribe is returned here
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to channel %s: %w", channel, err)
+	}
+
+	payloads := make(chan string)
+	go func() {
+		defer close(payloads)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case payloads <- message.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return payloads, nil
+}
+
 func (r *RedisClient) ExistsCache(ctx context.Context, key string) (bool, error) {
 	exists, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
@@ -82,6 +82,7 @@ func TestGetCacheNotExists(t *testing.T) {
 	value, err := redisClient.GetCache(ctx, key)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "cache key "+key+" does not exist")
+	assert.ErrorIs(t, err, ErrCacheMiss)
 	assert.Equal(t, "", value)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -137,6 +138,106 @@ func TestDeleteCacheError(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSetCacheNX(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	redisClient := &RedisClient{client: db}
+
+	ctx := context.Background()
+	key := "testKey"
+	value := "testValue"
+	expiration := 10 * time.Second
+
+	mock.ExpectSetNX(key, value, expiration).SetVal(true)
+	mock.ExpectSetNX(key, value, expiration).SetVal(false)
+
+	set, err := redisClient.SetCacheNX(ctx, key, value, expiration)
+	assert.NoError(t, err)
+	assert.True(t, set)
+
+	set, err = redisClient.SetCacheNX(ctx, key, value, expiration)
+	assert.NoError(t, err)
+	assert.False(t, set)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetCacheNXError(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	redisClient := &RedisClient{client: db}
+
+	ctx := context.Background()
+	key := "testKey"
+	value := "testValue"
+	expiration := 10 * time.Second
+
+	mock.ExpectSetNX(key, value, expiration).SetErr(errors.New("setnx error"))
+
+	set, err := redisClient.SetCacheNX(ctx, key, value, expiration)
+	assert.Error(t, err)
+	assert.False(t, set)
+	assert.Contains(t, err.Error(), "failed to set cache for key")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCacheIfEquals(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	redisClient := &RedisClient{client: db}
+
+	ctx := context.Background()
+	key := "testKey"
+	value := "testValue"
+
+	mock.ExpectEvalSha(deleteIfEquals.Hash(), []string{key}, value).SetVal(int64(1))
+	mock.ExpectEvalSha(deleteIfEquals.Hash(), []string{key}, value).SetVal(int64(0))
+
+	deleted, err := redisClient.DeleteCacheIfEquals(ctx, key, value)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = redisClient.DeleteCacheIfEquals(ctx, key, value)
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScanCache(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	redisClient := &RedisClient{client: db}
+
+	ctx := context.Background()
+
+	// Os caracteres especiais do prefixo não são tratados como padrão
+	mock.ExpectScan(0, `covid-api:v2:ranking:\**`, scanCount).SetVal([]string{"covid-api:v2:ranking:*:a"}, 7)
+	mock.ExpectScan(7, `covid-api:v2:ranking:\**`, scanCount).SetVal([]string{"covid-api:v2:ranking:*:b"}, 0)
+
+	keys, err := redisClient.ScanCache(ctx, "covid-api:v2:ranking:*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"covid-api:v2:ranking:*:a", "covid-api:v2:ranking:*:b"}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScanCacheError(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	redisClient := &RedisClient{client: db}
+
+	mock.ExpectScan(0, "prefix*", scanCount).SetErr(errors.New("scan error"))
+
+	keys, err := redisClient.ScanCache(context.Background(), "prefix")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to scan cache keys")
+	assert.Nil(t, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestExistsCache(t *testing.T) {
 	db, mock := redismock.NewClientMock()
 	defer db.Close()
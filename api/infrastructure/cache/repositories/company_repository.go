@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CachedCompanyRepository struct {
+	repo repositories.CompanyRepository
+	rt   *readThrough
+	ttls TTLs
+}
+
+// NewCachedCompanyRepository reads the queries of repo through cache.
+func NewCachedCompanyRepository(repo repositories.CompanyRepository, cache redis.Cache, ttls TTLs, logger logger.Logger) repositories.CompanyRepository {
+	return &CachedCompanyRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, logger, ttls),
+		ttls: ttls,
+	}
+}
+
+func (r *CachedCompanyRepository) ListCompanies(ctx context.Context) (*valueobjects.CompanyAdoption, error) {
+	key := cacheKey("companies")
+	return get(ctx, r.rt, key, r.ttls.Companies, func(loadCtx context.Context) (*valueobjects.CompanyAdoption, error) {
+		return r.repo.ListCompanies(loadCtx)
+	})
+}
+
+func (r *CachedCompanyRepository) GetRegionCompanies(ctx context.Context, regionName string) (*valueobjects.CompanyAdoption, error) {
+	key := cacheKey("region-companies", strings.TrimSpace(regionName))
+	return get(ctx, r.rt, key, r.ttls.Companies, func(loadCtx context.Context) (*valueobjects.CompanyAdoption, error) {
+		return r.repo.GetRegionCompanies(loadCtx, regionName)
+	})
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CachedCovidStatsRepository struct {
+	repo repositories.CovidStatsRepository
+	rt   *readThrough
+	ttls TTLs
+}
+
+// NewCachedCovidStatsRepository reads the queries of repo through cache.
+func NewCachedCovidStatsRepository(repo repositories.CovidStatsRepository, cache redis.Cache, ttls TTLs, logger logger.Logger) repositories.CovidStatsRepository {
+	return &CachedCovidStatsRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, logger, ttls),
+		ttls: ttls,
+	}
+}
+
+func (r *CachedCovidStatsRepository) GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.CovidStats, error) {
+	key := cacheKey("covid-totals", countryCode, date.Format(dateFormat), string(mode))
+	return get(ctx, r.rt, key, r.ttls.CovidTotals, func(loadCtx context.Context) (*valueobjects.CovidStats, error) {
+		return r.repo.GetTotalCasesAndDeaths(loadCtx, countryCode, date, mode)
+	})
+}
+
+func (r *CachedCovidStatsRepository) GetCovidSeries(ctx context.Context, countryCode string, from, to time.Time) ([]valueobjects.CovidStats, error) {
+	key := cacheKey("covid-series", countryCode, formatBound(from), formatBound(to))
+	return get(ctx, r.rt, key, r.ttls.CovidSeries, func(loadCtx context.Context) ([]valueobjects.CovidStats, error) {
+		return r.repo.GetCovidSeries(loadCtx, countryCode, from, to)
+	})
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+	"github.com/thalesmacedo1/covid-shared/events"
+)
+
+// Invalidator drops the cached results an ETL import changed, as soon as
+// the import announces it instead of when they expire.
+type Invalidator struct {
+	cache  redis.Cache
+	logger logger.Logger
+	// How long to wait before subscribing again after a failure
+	retry time.Duration
+}
+
+func NewInvalidator(cache redis.Cache, logger logger.Logger) *Invalidator {
+	return &Invalidator{
+		cache:  cache,
+		logger: logger,
+		retry:  bypassPeriod,
+	}
+}
+
+// Subscribe listens to the import events of the channel subscribe opens
+// until ctx is done. While Redis cannot be reached it tries again every
+// bypass period, like cached reads, so events are received once it is back.
+func (i *Invalidator) Subscribe(ctx context.Context, subscribe func(ctx context.Context) (<-chan string, error)) {
+	for {
+		payloads, err := subscribe(ctx)
+		if err == nil {
+			i.Listen(ctx, payloads)
+			err = errors.New("subscription closed")
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		i.logger.Warnf("Not receiving import events, cached results are only refreshed when they expire; retrying in %s: %v", i.retry, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(i.retry):
+		}
+	}
+}
+
+// Listen invalidates the results changed by every import completed event
+// received on payloads, until it is closed.
+func (i *Invalidator) Listen(ctx context.Context, payloads <-chan string) {
+	for payload := range payloads {
+		event, err := events.DecodeImportCompleted([]byte(payload))
+		if err != nil {
+			i.logger.Warnf("Ignoring import event: %v", err)
+			continue
+		}
+
+		deleted, err := i.Invalidate(ctx, event)
+		if err != nil {
+			i.logger.Warnf("Failed to invalidate the cache after the import completed at %s: %v", event.CompletedAt.Format(time.RFC3339), err)
+			continue
+		}
+		i.logger.Infof("Import completed at %s: dropped %d cached results", event.CompletedAt.Format(time.RFC3339), deleted)
+	}
+}
+
+// Invalidate deletes the entries of every cached query whose result event
+// may have changed, returning how many it deleted.
+func (i *Invalidator) Invalidate(ctx context.Context, event events.ImportCompleted) (int, error) {
+	keys, err := i.cache.ScanCache(ctx, cacheKey(""))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if !changedBy(key, event) {
+			continue
+		}
+		if err := i.cache.DeleteCache(ctx, key); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// changedBy reports whether the query cached under key may return something
+// else after event. Keys it cannot parse are assumed changed.
+func changedBy(key string, event events.ImportCompleted) bool {
+	// Recompute locks expire on their own
+	if strings.HasSuffix(key, ":lock") {
+		return false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(key, cacheKey("")), ":")
+	query, args := parts[0], parts[1:]
+	argsAtLeast := func(n int) bool { return len(args) >= n }
+
+	switch {
+	case query == "covid-totals" && argsAtLeast(3):
+		return changes(event, events.DatasetCovid, args[0], atDate(args[1], args[2]))
+	case query == "covid-series" && argsAtLeast(3):
+		return changes(event, events.DatasetCovid, args[0], between(args[1], args[2]))
+	case query == "vaccinated-people" && argsAtLeast(3):
+		return changes(event, events.DatasetVaccinations, args[0], atDate(args[1], args[2]))
+	case query == "vaccines-used" && argsAtLeast(1):
+		return changes(event, events.DatasetVaccines, args[0], nil)
+	case query == "vaccines":
+		return changes(event, events.DatasetVaccines, "", nil)
+	case query == "vaccine-countries":
+		// The countries are listed with their names and regions
+		return changes(event, events.DatasetVaccines, "", nil) || changes(event, events.DatasetVaccinations, "", nil)
+	case query == "companies" || query == "region-companies":
+		// Companies come with the vaccines; the countries counted, with the vaccinations
+		return changes(event, events.DatasetVaccines, "", nil) || changes(event, events.DatasetVaccinations, "", nil)
+	case query == "region-vaccine-usage":
+		// Which countries are in the region and their doses come from the vaccination data
+		return changes(event, events.DatasetVaccines, "", nil) || changes(event, events.DatasetVaccinations, "", nil)
+	case query == "ranking" && argsAtLeast(3):
+		return changes(event, events.DatasetCovid, "", atDate(args[1], args[2])) ||
+			changes(event, events.DatasetVaccinations, "", atDate(args[1], args[2]))
+	default:
+		return true
+	}
+}
+
+// changes reports whether event imported dataset rows for country, or for
+// any country when it is empty, dated within what sees reports as visible.
+// A nil sees matches any date.
+func changes(event events.ImportCompleted, dataset events.Dataset, country string, sees func(events.DateRange) bool) bool {
+	for _, imported := range event.Imports {
+		if imported.Dataset != dataset {
+			continue
+		}
+		if country != "" && !slices.Contains(imported.Countries, country) {
+			continue
+		}
+		if sees == nil || imported.Dates == nil || sees(*imported.Dates) {
+			return true
+		}
+	}
+	return false
+}
+
+// atDate is what a query at date in mode sees: only that date when exact,
+// and any date up to it as of it, since a report dated before it can become
+// the latest one.
+func atDate(date, mode string) func(events.DateRange) bool {
+	day, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return nil
+	}
+	return func(dates events.DateRange) bool {
+		if day.Before(dates.From) {
+			return false
+		}
+		return mode != string(valueobjects.DateModeExact) || !day.After(dates.To)
+	}
+}
+
+// between is what a series between the bounds formatted by formatBound sees.
+func between(from, to string) func(events.DateRange) bool {
+	start, startErr := time.Parse(dateFormat, from)
+	end, endErr := time.Parse(dateFormat, to)
+	return func(dates events.DateRange) bool {
+		if endErr == nil && end.Before(dates.From) {
+			return false
+		}
+		return startErr != nil || !start.After(dates.To)
+	}
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+	"github.com/thalesmacedo1/covid-shared/events"
+)
+
+func day(value string) time.Time {
+	date, err := time.Parse(dateFormat, value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+// testImport imported February 2024 cases of Brazil and Argentina and the
+// vaccines of Chile.
+var testImport = events.ImportCompleted{
+	CompletedAt: time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC),
+	Imports: []events.DatasetImport{
+		{Dataset: events.DatasetCovid, Countries: []string{"ARG", "BRA"}, Dates: &events.DateRange{From: day("2024-02-01"), To: day("2024-02-29")}},
+		{Dataset: events.DatasetVaccines, Countries: []string{"CHL"}},
+	},
+}
+
+func TestChangedBy(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: cacheKey("covid-totals", "BRA", "2024-02-10", "exact"), want: true},
+		{key: cacheKey("covid-totals", "BRA", "2024-03-10", "exact"), want: false},
+		{key: cacheKey("covid-totals", "BRA", "2024-03-10", "as_of"), want: true},
+		{key: cacheKey("covid-totals", "BRA", "2024-01-31", "as_of"), want: false},
+		{key: cacheKey("covid-totals", "CHL", "2024-02-10", "exact"), want: false},
+		{key: cacheKey("covid-series", "ARG", "-", "-"), want: true},
+		{key: cacheKey("covid-series", "ARG", "2023-01-01", "2024-01-31"), want: false},
+		{key: cacheKey("covid-series", "ARG", "2024-02-29", "-"), want: true},
+		{key: cacheKey("covid-series", "ARG", "2024-03-01", "-"), want: false},
+		{key: cacheKey("vaccinated-people", "BRA", "2024-02-10", "as_of"), want: false},
+		{key: cacheKey("vaccines-used", "CHL"), want: true},
+		{key: cacheKey("vaccines-used", "BRA"), want: false},
+		{key: cacheKey("region-vaccine-usage", "AMRO"), want: true},
+		{key: cacheKey("vaccines"), want: true},
+		{key: cacheKey("companies"), want: true},
+		{key: cacheKey("region-companies", "AMRO"), want: true},
+		{key: cacheKey("vaccine-countries", "SII - Covishield", ""), want: true},
+		{key: cacheKey("ranking", "cumulative_cases", "2024-02-15", "as_of", "desc", "", "10"), want: true},
+		{key: cacheKey("ranking", "cumulative_cases", "2024-01-15", "as_of", "desc", "", "10"), want: false},
+		{key: cacheKey("vaccines-used", "CHL") + ":lock", want: false},
+		{key: cacheKey("unknown-query", "BRA"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, changedBy(tt.key, testImport))
+		})
+	}
+}
+
+func TestInvalidatorDeletesChangedEntries(t *testing.T) {
+	cache := newMapCache()
+	ctx := context.Background()
+	changed := cacheKey("covid-totals", "BRA", "2024-02-10", "exact")
+	unchanged := cacheKey("covid-totals", "BRA", "2024-01-10", "exact")
+	other := "other-app:BRA"
+	for _, key := range []string{changed, unchanged, other} {
+		cache.putEntry(t, key, []int{1}, -time.Hour)
+	}
+
+	deleted, err := NewInvalidator(cache, logger.NewLogrusLogger()).Invalidate(ctx, testImport)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	for key, wantExists := range map[string]bool{changed: false, unchanged: true, other: true} {
+		exists, _ := cache.ExistsCache(ctx, key)
+		assert.Equal(t, wantExists, exists, key)
+	}
+}
+
+func TestInvalidatorListens(t *testing.T) {
+	cache := newMapCache()
+	key := cacheKey("vaccines-used", "CHL")
+	cache.putEntry(t, key, []int{1}, -time.Hour)
+
+	payload, err := testImport.Encode()
+	assert.NoError(t, err)
+
+	payloads := make(chan string, 2)
+	payloads <- "not an event"
+	payloads <- string(payload)
+	close(payloads)
+	NewInvalidator(cache, logger.NewLogrusLogger()).Listen(context.Background(), payloads)
+
+	exists, _ := cache.ExistsCache(context.Background(), key)
+	assert.False(t, exists)
+}
+
+func TestInvalidatorSubscribeRetries(t *testing.T) {
+	cache := newMapCache()
+	key := cacheKey("vaccines-used", "CHL")
+	cache.putEntry(t, key, []int{1}, -time.Hour)
+
+	payload, err := testImport.Encode()
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	invalidator := NewInvalidator(cache, logger.NewLogrusLogger())
+	invalidator.retry = time.Millisecond
+	invalidator.Subscribe(ctx, func(ctx context.Context) (<-chan string, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection refused")
+		}
+
+		// Stop once the second subscription delivered its event
+		payloads := make(chan string, 1)
+		payloads <- string(payload)
+		close(payloads)
+		cancel()
+		return payloads, nil
+	})
+
+	assert.Equal(t, 2, attempts)
+	exists, _ := cache.ExistsCache(context.Background(), key)
+	assert.False(t, exists)
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CachedRankingRepository struct {
+	repo repositories.RankingRepository
+	rt   *readThrough
+	ttls TTLs
+}
+
+// NewCachedRankingRepository reads the queries of repo through cache.
+func NewCachedRankingRepository(repo repositories.RankingRepository, cache redis.Cache, ttls TTLs, logger logger.Logger) repositories.RankingRepository {
+	return &CachedRankingRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, logger, ttls),
+		ttls: ttls,
+	}
+}
+
+func (r *CachedRankingRepository) GetRanking(ctx context.Context, query valueobjects.RankingQuery) ([]valueobjects.RankingEntry, error) {
+	key := cacheKey("ranking", string(query.Metric), query.Date.Format(dateFormat), string(query.Mode),
+		string(query.Order), query.Region, strconv.Itoa(query.Limit))
+	return get(ctx, r.rt, key, r.ttls.Ranking, func(loadCtx context.Context) ([]valueobjects.RankingEntry, error) {
+		return r.repo.GetRanking(loadCtx, query)
+	})
+}
@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+// cacheVersion is part of every key, so changing how entries are encoded
+// only takes a version bump instead of a flush.
+const cacheVersion = "v2"
+
+// bypassPeriod is how long reads skip the cache after it fails, so an
+// unavailable Redis costs one slow request instead of every request.
+const bypassPeriod = 30 * time.Second
+
+// lockPollInterval is how often a caller waiting for another replica to
+// compute a missing entry checks whether it is there yet.
+const lockPollInterval = 50 * time.Millisecond
+
+const dateFormat = "2006-01-02"
+
+// errUndecodable is returned by decodeEntry for entries that do not hold the
+// expected type, which are then treated as missing.
+var errUndecodable = errors.New("undecodable cache entry")
+
+// TTLs is how long each cached query stays fresh. NotFound applies to
+// queries that found no data. Entries are kept Stale longer, served while
+// one caller recomputes them, and Lock bounds how long that caller may take
+// before another one tries.
+type TTLs struct {
+	CovidTotals      time.Duration
+	CovidSeries      time.Duration
+	VaccinatedPeople time.Duration
+	VaccinesUsed     time.Duration
+	MostUsedVaccine  time.Duration
+	Companies        time.Duration
+	Ranking          time.Duration
+	NotFound         time.Duration
+	Stale            time.Duration
+	Lock             time.Duration
+}
+
+// cacheEntry is what is stored under a key: either the query result or the
+// message of the not-found error it returned, and when it stops being fresh.
+type cacheEntry struct {
+	Value      json.RawMessage `json:"value,omitempty"`
+	NotFound   string          `json:"notFound,omitempty"`
+	FreshUntil time.Time       `json:"freshUntil"`
+}
+
+func (e *cacheEntry) fresh() bool {
+	return time.Now().Before(e.FreshUntil)
+}
+
+// readThrough serves queries from the cache, loading and storing them on a
+// miss. Identical lookups in flight in this process share one load, and
+// across processes a short lock lets a single caller recompute an expired
+// entry while the others keep serving the stale one. Cache failures are
+// logged and answered from the repository.
+type readThrough struct {
+	cache       redis.Cache
+	logger      logger.Logger
+	ttls        TTLs
+	group       singleflight.Group
+	bypassUntil atomic.Int64
+}
+
+func newReadThrough(cache redis.Cache, logger logger.Logger, ttls TTLs) *readThrough {
+	return &readThrough{
+		cache:  cache,
+		logger: logger,
+		ttls:   ttls,
+	}
+}
+
+// cacheKey builds the key of a query from its name and arguments.
+func cacheKey(query string, args ...string) string {
+	return redis.GenerateCacheKey(append([]string{"covid-api", cacheVersion, query}, args...)...)
+}
+
+// get returns the cached result of key, or calls load and caches what it
+// returns for ttl. Not-found errors are cached for the not-found TTL and
+// returned again on later hits; other errors are not cached. load must read
+// with the context it is given, which is not cancelled with any one caller.
+func get[T any](ctx context.Context, rt *readThrough, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	if !rt.available() {
+		return load(ctx)
+	}
+
+	entry := rt.lookup(ctx, key)
+	if entry != nil {
+		value, err := decodeEntry[T](entry)
+		if errors.Is(err, errUndecodable) {
+			rt.logger.Warnf("Ignoring undecodable cache entry %s", key)
+			entry = nil
+		} else if entry.fresh() {
+			return value, err
+		}
+	}
+
+	// The shared call outlives the caller that started it, so it must not be
+	// cancelled with that caller's request. A caller that goes away stops
+	// waiting for it while the others still get its result
+	shared := context.WithoutCancel(ctx)
+	call := rt.group.DoChan(key, func() (interface{}, error) {
+		return refresh(shared, rt, key, ttl, entry, load)
+	})
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case result := <-call:
+		value, _ := result.Val.(T)
+		return value, result.Err
+	}
+}
+
+// refresh recomputes key, or returns the stale entry when another process
+// already is. Without any entry it waits for that process instead, up to the
+// lock TTL.
+func refresh[T any](ctx context.Context, rt *readThrough, key string, ttl time.Duration, stale *cacheEntry, load func(ctx context.Context) (T, error)) (T, error) {
+	token, locked, err := rt.lock(ctx, key)
+	if err != nil {
+		rt.fail(key, err)
+		return load(ctx)
+	}
+
+	if !locked {
+		if stale == nil {
+			stale = rt.wait(ctx, key)
+		}
+		if stale != nil {
+			if value, err := decodeEntry[T](stale); !errors.Is(err, errUndecodable) {
+				return value, err
+			}
+		}
+		return load(ctx)
+	}
+	defer rt.unlock(ctx, key, token)
+
+	value, err := load(ctx)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return value, err
+	}
+
+	rt.store(ctx, key, value, err, ttl)
+	return value, err
+}
+
+// decodeEntry returns the result stored in entry.
+func decodeEntry[T any](entry *cacheEntry) (T, error) {
+	var value T
+	if entry.NotFound != "" {
+		return value, domain.NotFoundf("%s", entry.NotFound)
+	}
+	if err := json.Unmarshal(entry.Value, &value); err != nil {
+		return value, errUndecodable
+	}
+	return value, nil
+}
+
+// lookup returns the entry of key, or nil on a miss or a cache failure.
+func (rt *readThrough) lookup(ctx context.Context, key string) *cacheEntry {
+	var entry cacheEntry
+	if err := rt.cache.GetCacheJSON(ctx, key, &entry); err != nil {
+		if !errors.Is(err, redis.ErrCacheMiss) {
+			rt.fail(key, err)
+		}
+		return nil
+	}
+	return &entry
+}
+
+// wait polls for the entry of key while another process computes it.
+func (rt *readThrough) wait(ctx context.Context, key string) *cacheEntry {
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(rt.ttls.Lock)
+
+	for rt.available() {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-deadline:
+			return nil
+		case <-ticker.C:
+			if entry := rt.lookup(ctx, key); entry != nil {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+// lock takes the recompute lock of key, returning the token that releases
+// it.
+func (rt *readThrough) lock(ctx context.Context, key string) (string, bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", false, err
+	}
+	token := hex.EncodeToString(buf)
+
+	locked, err := rt.cache.SetCacheNX(ctx, key+":lock", token, rt.ttls.Lock)
+	return token, locked, err
+}
+
+func (rt *readThrough) unlock(ctx context.Context, key, token string) {
+	if _, err := rt.cache.DeleteCacheIfEquals(ctx, key+":lock", token); err != nil {
+		rt.fail(key, err)
+	}
+}
+
+// store caches value as fresh for ttl, or the message of notFound for the
+// not-found TTL when it is set, keeping either for the stale period after.
+func (rt *readThrough) store(ctx context.Context, key string, value interface{}, notFound error, ttl time.Duration) {
+	entry := cacheEntry{}
+	if notFound != nil {
+		entry.NotFound, ttl = notFound.Error(), rt.ttls.NotFound
+	} else {
+		data, err := json.Marshal(value)
+		if err != nil {
+			rt.logger.Warnf("Failed to encode cache entry %s: %v", key, err)
+			return
+		}
+		entry.Value = data
+	}
+	entry.FreshUntil = time.Now().Add(ttl)
+
+	if err := rt.cache.SetCacheJSON(ctx, key, entry, ttl+rt.ttls.Stale); err != nil {
+		rt.fail(key, err)
+	}
+}
+
+func (rt *readThrough) available() bool {
+	return time.Now().UnixNano() >= rt.bypassUntil.Load()
+}
+
+// fail logs a cache error and bypasses the cache for a while, unless the
+// request itself was cancelled.
+func (rt *readThrough) fail(key string, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	rt.logger.Warnf("Cache unavailable, reading %s without it for %s: %v", key, bypassPeriod, err)
+	rt.bypassUntil.Store(time.Now().Add(bypassPeriod).UnixNano())
+}
+
+// formatBound formats an optional date argument, with "-" for the zero time.
+func formatBound(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(dateFormat)
+}
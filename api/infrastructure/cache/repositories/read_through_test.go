@@ -0,0 +1,329 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+// mapCache is a Cache kept in a map that fails every call while err is set.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+	ttls    map[string]time.Duration
+	err     error
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{entries: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *mapCache) SetCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.entries[key] = fmt.Sprint(value)
+	c.ttls[key] = expiration
+	return nil
+}
+
+func (c *mapCache) GetCache(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return "", c.err
+	}
+	value, ok := c.entries[key]
+	if !ok {
+		return "", fmt.Errorf("cache key %s does not exist: %w", key, redis.ErrCacheMiss)
+	}
+	return value, nil
+}
+
+func (c *mapCache) DeleteCache(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return c.err
+}
+
+func (c *mapCache) SetCacheNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false, c.err
+	}
+	if _, ok := c.entries[key]; ok {
+		return false, nil
+	}
+	c.entries[key] = fmt.Sprint(value)
+	c.ttls[key] = expiration
+	return true, nil
+}
+
+func (c *mapCache) DeleteCacheIfEquals(ctx context.Context, key string, value string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false, c.err
+	}
+	if c.entries[key] != value {
+		return false, nil
+	}
+	delete(c.entries, key)
+	return true, nil
+}
+
+func (c *mapCache) ExistsCache(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok, c.err
+}
+
+func (c *mapCache) ScanCache(ctx context.Context, prefix string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	var keys []string
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (c *mapCache) SetCacheJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.SetCache(ctx, key, string(data), expiration)
+}
+
+func (c *mapCache) GetCacheJSON(ctx context.Context, key string, dest interface{}) error {
+	data, err := c.GetCache(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), dest)
+}
+
+// putEntry stores value under key as an entry that stopped being fresh age
+// ago, or is still fresh for a negative age.
+func (c *mapCache) putEntry(t *testing.T, key string, value interface{}, age time.Duration) {
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	entry := cacheEntry{Value: data, FreshUntil: time.Now().Add(-age)}
+	assert.NoError(t, c.SetCacheJSON(context.Background(), key, entry, time.Hour))
+}
+
+// counter loads the result and error it holds, counting the calls. Like a
+// database read, it gives up when its context is cancelled.
+type counter struct {
+	calls  atomic.Int32
+	delay  time.Duration
+	result []int
+	err    error
+}
+
+func (c *counter) load(ctx context.Context) ([]int, error) {
+	c.calls.Add(1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+	}
+	return c.result, c.err
+}
+
+var testTTLs = TTLs{NotFound: time.Minute, Stale: 10 * time.Minute, Lock: time.Second}
+
+func newTestReadThrough(cache redis.Cache) *readThrough {
+	return newReadThrough(cache, logger.NewLogrusLogger(), testTTLs)
+}
+
+func TestReadThroughCachesResults(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{1, 2}}
+
+	for i := 0; i < 2; i++ {
+		result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, result)
+	}
+	assert.EqualValues(t, 1, source.calls.Load())
+	assert.Equal(t, time.Hour+testTTLs.Stale, cache.ttls["key"])
+	assert.NotContains(t, cache.entries, "key:lock")
+}
+
+func TestReadThroughCachesNotFound(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{err: domain.NotFoundf("no data for XYZ")}
+
+	for i := 0; i < 2; i++ {
+		_, err := get(context.Background(), rt, "key", time.Hour, source.load)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.EqualError(t, err, "no data for XYZ")
+	}
+	assert.EqualValues(t, 1, source.calls.Load())
+	assert.Equal(t, testTTLs.NotFound+testTTLs.Stale, cache.ttls["key"])
+}
+
+func TestReadThroughDoesNotCacheFailures(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{err: errors.New("connection refused")}
+
+	for i := 0; i < 2; i++ {
+		_, err := get(context.Background(), rt, "key", time.Hour, source.load)
+		assert.EqualError(t, err, "connection refused")
+	}
+	assert.EqualValues(t, 2, source.calls.Load())
+	assert.NotContains(t, cache.entries, "key")
+}
+
+func TestReadThroughBypassesUnavailableCache(t *testing.T) {
+	cache := newMapCache()
+	cache.err = errors.New("dial tcp: connection refused")
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{1}}
+
+	result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, result)
+	assert.False(t, rt.available())
+
+	// Redis is back, but reads keep skipping it until the bypass period ends
+	cache.err = nil
+	_, err = get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, source.calls.Load())
+	assert.Empty(t, cache.entries)
+
+	rt.bypassUntil.Store(0)
+	_, err = get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Contains(t, cache.entries, "key")
+}
+
+func TestReadThroughCoalescesConcurrentMisses(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{1}, delay: 50 * time.Millisecond}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1}, result)
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, source.calls.Load())
+}
+
+func TestReadThroughSurvivesCancelledCaller(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{1}, delay: 100 * time.Millisecond}
+
+	// The first caller starts the load and goes away while it runs
+	first, cancel := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := get(first, rt, "key", time.Hour, source.load)
+		firstDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	secondDone := make(chan []int, 1)
+	go func() {
+		result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+		assert.NoError(t, err)
+		secondDone <- result
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-firstDone, context.Canceled)
+	assert.Equal(t, []int{1}, <-secondDone)
+	assert.EqualValues(t, 1, source.calls.Load())
+	assert.Contains(t, cache.entries, "key")
+}
+
+func TestReadThroughServesStaleWhileLocked(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{2}}
+
+	cache.putEntry(t, "key", []int{1}, time.Minute)
+	cache.entries["key:lock"] = "another replica"
+
+	result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, result)
+	assert.EqualValues(t, 0, source.calls.Load())
+}
+
+func TestReadThroughRecomputesStaleEntry(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{2}}
+
+	cache.putEntry(t, "key", []int{1}, time.Minute)
+
+	result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, result)
+	assert.EqualValues(t, 1, source.calls.Load())
+	assert.NotContains(t, cache.entries, "key:lock")
+
+	result, err = get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, result)
+	assert.EqualValues(t, 1, source.calls.Load())
+}
+
+func TestReadThroughWaitsForOtherReplica(t *testing.T) {
+	cache := newMapCache()
+	rt := newTestReadThrough(cache)
+	source := &counter{result: []int{2}}
+
+	cache.entries["key:lock"] = "another replica"
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cache.putEntry(t, "key", []int{1}, -time.Hour)
+	}()
+
+	result, err := get(context.Background(), rt, "key", time.Hour, source.load)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, result)
+	assert.EqualValues(t, 0, source.calls.Load())
+}
+
+func TestCacheKey(t *testing.T) {
+	assert.Equal(t, "covid-api:v2:covid-series:BRA:2023-01-01:-", cacheKey("covid-series", "BRA", "2023-01-01", formatBound(time.Time{})))
+}
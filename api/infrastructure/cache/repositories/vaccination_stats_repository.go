@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CachedVaccinationStatsRepository struct {
+	repo repositories.VaccinationStatsRepository
+	rt   *readThrough
+	ttls TTLs
+}
+
+// NewCachedVaccinationStatsRepository reads the queries of repo through
+// cache.
+func NewCachedVaccinationStatsRepository(repo repositories.VaccinationStatsRepository, cache redis.Cache, ttls TTLs, logger logger.Logger) repositories.VaccinationStatsRepository {
+	return &CachedVaccinationStatsRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, logger, ttls),
+		ttls: ttls,
+	}
+}
+
+func (r *CachedVaccinationStatsRepository) GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.VaccinationStats, error) {
+	key := cacheKey("vaccinated-people", countryCode, date.Format(dateFormat), string(mode))
+	return get(ctx, r.rt, key, r.ttls.VaccinatedPeople, func(loadCtx context.Context) (*valueobjects.VaccinationStats, error) {
+		return r.repo.GetVaccinatedPeople(loadCtx, countryCode, date, mode)
+	})
+}
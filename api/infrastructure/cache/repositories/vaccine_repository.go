@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/cache/redis"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CachedVaccineRepository struct {
+	repo repositories.VaccineRepository
+	rt   *readThrough
+	ttls TTLs
+}
+
+// NewCachedVaccineRepository reads the queries of repo through cache.
+func NewCachedVaccineRepository(repo repositories.VaccineRepository, cache redis.Cache, ttls TTLs, logger logger.Logger) repositories.VaccineRepository {
+	return &CachedVaccineRepository{
+		repo: repo,
+		rt:   newReadThrough(cache, logger, ttls),
+		ttls: ttls,
+	}
+}
+
+func (r *CachedVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCode string) ([]struct {
+	Vaccine           entities.Vaccine
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
+}, error) {
+	key := cacheKey("vaccines-used", countryCode)
+	return get(ctx, r.rt, key, r.ttls.VaccinesUsed, func(loadCtx context.Context) ([]struct {
+		Vaccine           entities.Vaccine
+		StartDate         *time.Time
+		AuthorizationDate *time.Time
+		EndDate           *time.Time
+	}, error) {
+		return r.repo.GetVaccinesUsed(loadCtx, countryCode)
+	})
+}
+
+func (r *CachedVaccineRepository) GetRegionVaccineUsage(ctx context.Context, regionName string) ([]valueobjects.CountryVaccineUsage, error) {
+	key := cacheKey("region-vaccine-usage", strings.TrimSpace(regionName))
+	return get(ctx, r.rt, key, r.ttls.MostUsedVaccine, func(loadCtx context.Context) ([]valueobjects.CountryVaccineUsage, error) {
+		return r.repo.GetRegionVaccineUsage(loadCtx, regionName)
+	})
+}
+
+func (r *CachedVaccineRepository) ListVaccines(ctx context.Context) ([]valueobjects.VaccineSummary, error) {
+	key := cacheKey("vaccines")
+	return get(ctx, r.rt, key, r.ttls.VaccinesUsed, func(loadCtx context.Context) ([]valueobjects.VaccineSummary, error) {
+		return r.repo.ListVaccines(loadCtx)
+	})
+}
+
+func (r *CachedVaccineRepository) GetVaccineCountries(ctx context.Context, product string, regionName string) (*valueobjects.VaccineAdoption, error) {
+	key := cacheKey("vaccine-countries", strings.TrimSpace(product), strings.TrimSpace(regionName))
+	return get(ctx, r.rt, key, r.ttls.VaccinesUsed, func(loadCtx context.Context) (*valueobjects.VaccineAdoption, error) {
+		return r.repo.GetVaccineCountries(loadCtx, product, regionName)
+	})
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type Neo4jCompanyRepository struct {
+	driver neo4j.DriverWithContext
+}
+
+func NewNeo4jCompanyRepository(driver neo4j.DriverWithContext) repositories.CompanyRepository {
+	return &Neo4jCompanyRepository{
+		driver: driver,
+	}
+}
+
+func (r *Neo4jCompanyRepository) ListCompanies(ctx context.Context) (*valueobjects.CompanyAdoption, error) {
+	return r.companyAdoption(ctx, "")
+}
+
+func (r *Neo4jCompanyRepository) GetRegionCompanies(ctx context.Context, regionName string) (*valueobjects.CompanyAdoption, error) {
+	regionName = strings.TrimSpace(regionName)
+	if regionName == "" {
+		return nil, domain.InvalidInputf("region name cannot be empty")
+	}
+	return r.companyAdoption(ctx, regionName)
+}
+
+// companyAdoption counts, for every company, the countries that use at least
+// one of its vaccines. Only the countries of regionName are counted unless it
+// is empty, in which case companies no country uses are kept as well.
+func (r *Neo4jCompanyRepository) companyAdoption(ctx context.Context, regionName string) (*valueobjects.CompanyAdoption, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	membersQuery := `
+	MATCH (c:Country)
+	WHERE $region IS NULL OR EXISTS { (c)-[:BELONGS]->(:Region {name: $region}) }
+	RETURN count(c) AS members
+	`
+
+	companiesQuery := `
+	MATCH (co:Company)-[:PRODUCES]->(v:Vaccine)
+	OPTIONAL MATCH (v)<-[:USES]-(c:Country)
+	WHERE $region IS NULL OR EXISTS { (c)-[:BELONGS]->(:Region {name: $region}) }
+	WITH co, count(DISTINCT v) AS products,
+		 count(DISTINCT CASE WHEN c IS NOT NULL THEN v END) AS productsUsed,
+		 count(DISTINCT c) AS countries
+	WHERE $region IS NULL OR countries > 0
+	RETURN co.name AS name, products, productsUsed, countries
+	ORDER BY countries DESC, name
+	`
+
+	var region interface{}
+	if regionName != "" {
+		region = regionName
+	}
+	params := map[string]interface{}{
+		"region": region,
+	}
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, membersQuery, params)
+		if err != nil {
+			return nil, err
+		}
+		record, err := rec.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		members, _, _ := neo4j.GetRecordValue[int64](record, "members")
+
+		if regionName != "" && members == 0 {
+			return nil, domain.NotFoundf("region %s not found", regionName)
+		}
+
+		rec, err = tx.Run(ctx, companiesQuery, params)
+		if err != nil {
+			return nil, err
+		}
+
+		adoption := &valueobjects.CompanyAdoption{
+			MemberCountries: int(members),
+			Companies:       []valueobjects.CompanyUsage{},
+		}
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			name, _, _ := neo4j.GetRecordValue[string](record, "name")
+			products, _, _ := neo4j.GetRecordValue[int64](record, "products")
+			productsUsed, _, _ := neo4j.GetRecordValue[int64](record, "productsUsed")
+			countries, _, _ := neo4j.GetRecordValue[int64](record, "countries")
+
+			company, err := entities.NewCompany(name)
+			if err != nil {
+				return nil, corruptRecord("company", err)
+			}
+
+			adoption.Companies = append(adoption.Companies, valueobjects.CompanyUsage{
+				Company:      *company,
+				Products:     int(products),
+				ProductsUsed: int(productsUsed),
+				Countries:    int(countries),
+			})
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return adoption, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.(*valueobjects.CompanyAdoption), nil
+}
@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/entities"
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-shared/iso3166"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
@@ -29,37 +31,152 @@ func (r *Neo4jCountryRepository) GetCountryByCode(ctx context.Context, code stri
 
 	query := `
 	MATCH (c:Country {code: $code})
-	RETURN c.name AS name, c.code AS code
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric
 	`
 
+	// Countries are keyed by alpha-3; accept the alpha-2 and numeric forms too
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if country, ok := iso3166.Lookup(code); ok {
+		code = country.Alpha3
+	}
+
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		rec, err := tx.Run(ctx, query, map[string]interface{}{
-			"code": strings.ToUpper(strings.TrimSpace(code)),
+			"code": code,
 		})
 		if err != nil {
 			return nil, err
 		}
 
 		if rec.Next(ctx) {
-			name, _ := rec.Record().Get("name")
-			code, _ := rec.Record().Get("code")
-			return entities.NewCountry(code.(string), name.(string))
+			return countryFromRecord(rec.Record())
 		}
 
 		if err = rec.Err(); err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("country with code %s not found", code)
+		return nil, domain.NotFoundf("country with code %s not found", code)
 	}))
 
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 
 	return result.(*entities.Country), nil
 }
 
+func (r *Neo4jCountryRepository) ListCountries(ctx context.Context, filter valueobjects.CountryFilter) ([]valueobjects.CountrySummary, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+	MATCH (c:Country)
+	WHERE $region IS NULL OR EXISTS { (c)-[:BELONGS]->(:Region {name: $region}) }
+	OPTIONAL MATCH (c)-[:BELONGS]->(r:Region)
+	WITH c, coalesce($region, min(r.name)) AS region
+	CALL {
+		WITH c
+		OPTIONAL MATCH (c)-[:REPORTED_ON]->(cs:CovidStats)
+		RETURN min(cs.date) AS covidFrom, max(cs.date) AS covidTo
+	}
+	CALL {
+		WITH c
+		OPTIONAL MATCH (c)-[:VACCINATED_ON]->(vs:VaccinationStats)
+		RETURN min(vs.date) AS vaccinationFrom, max(vs.date) AS vaccinationTo
+	}
+	WITH c, region, covidFrom, covidTo, vaccinationFrom, vaccinationTo
+	WHERE $hasVaccinationData IS NULL OR (vaccinationTo IS NOT NULL) = $hasVaccinationData
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric, region,
+		   covidFrom, covidTo, vaccinationFrom, vaccinationTo
+	ORDER BY code
+	`
+
+	var region, hasVaccinationData interface{}
+	if filter.Region != "" {
+		region = strings.TrimSpace(filter.Region)
+	}
+	if filter.HasVaccinationData != nil {
+		hasVaccinationData = *filter.HasVaccinationData
+	}
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, map[string]interface{}{
+			"region":             region,
+			"hasVaccinationData": hasVaccinationData,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		countries := []valueobjects.CountrySummary{}
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, err
+			}
+
+			summary := valueobjects.CountrySummary{Country: *country}
+			summary.Region, _, _ = neo4j.GetRecordValue[string](record, "region")
+			summary.Covid = dateCoverageFromRecord(record, "covidFrom", "covidTo")
+			summary.Vaccination = dateCoverageFromRecord(record, "vaccinationFrom", "vaccinationTo")
+
+			countries = append(countries, summary)
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return countries, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.CountrySummary), nil
+}
+
+// dateCoverageFromRecord builds a DateCoverage from two date columns of
+// record, leaving the zero time for absent dates.
+func dateCoverageFromRecord(record *neo4j.Record, fromKey, toKey string) valueobjects.DateCoverage {
+	var coverage valueobjects.DateCoverage
+
+	from, _ := record.Get(fromKey)
+	if from := nullableDate(from); from != nil {
+		coverage.From = *from
+	}
+	to, _ := record.Get(toKey)
+	if to := nullableDate(to); to != nil {
+		coverage.To = *to
+	}
+
+	return coverage
+}
+
+// countryFromRecord builds a Country from the name, code, iso2, iso3 and
+// numeric columns of record.
+func countryFromRecord(record *neo4j.Record) (*entities.Country, error) {
+	name, _, _ := neo4j.GetRecordValue[string](record, "name")
+	code, _, _ := neo4j.GetRecordValue[string](record, "code")
+
+	country, err := entities.NewCountry(code, name)
+	if err != nil {
+		return nil, corruptRecord("country", err)
+	}
+
+	country.ISO2, _, _ = neo4j.GetRecordValue[string](record, "iso2")
+	country.ISO3, _, _ = neo4j.GetRecordValue[string](record, "iso3")
+	country.Numeric, _, _ = neo4j.GetRecordValue[string](record, "numeric")
+
+	return country, nil
+}
+
 func (r *Neo4jCountryRepository) CreateCountry(ctx context.Context, country *entities.Country) error {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeWrite,
@@ -78,7 +195,7 @@ func (r *Neo4jCountryRepository) CreateCountry(ctx context.Context, country *ent
 		return nil, err
 	}))
 
-	return err
+	return queryError(err)
 }
 
 func (r *Neo4jCountryRepository) AssociateCountryWithRegion(ctx context.Context, countryCode, regionName string) error {
@@ -100,7 +217,7 @@ func (r *Neo4jCountryRepository) AssociateCountryWithRegion(ctx context.Context,
 		return nil, err
 	}))
 
-	return err
+	return queryError(err)
 }
 
 func (r *Neo4jCountryRepository) AddRegionToCountry(ctx context.Context, countryCode string, region *entities.Region) error {
@@ -123,5 +240,5 @@ func (r *Neo4jCountryRepository) AddRegionToCountry(ctx context.Context, country
 		return nil, err
 	}))
 
-	return err
+	return queryError(err)
 }
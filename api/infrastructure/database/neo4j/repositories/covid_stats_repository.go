@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
 	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 
@@ -25,16 +26,21 @@ func NewNeo4jCovidStatsRepository(driver neo4j.DriverWithContext) repositories.C
 	}
 }
 
-func (r *Neo4jCovidStatsRepository) GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time) (*valueobjects.CovidStats, error) {
+// GetTotalCasesAndDeaths returns the report of countryCode on date, or with
+// DateModeAsOf its latest report on or before date.
+func (r *Neo4jCovidStatsRepository) GetTotalCasesAndDeaths(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.CovidStats, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeRead,
 	})
 	defer session.Close(ctx)
 
-	query := `
-	MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date {date: $date})
-	RETURN cs.cumulativeCases AS cumulativeCases, cs.newCases AS newCases, cs.cumulativeDeaths AS cumulativeDeaths, cs.newDeaths AS newDeaths
-	`
+	query := fmt.Sprintf(`
+	MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)
+	WHERE %s
+	RETURN cs.date AS date, cs.cumulativeCases AS cumulativeCases, cs.newCases AS newCases, cs.cumulativeDeaths AS cumulativeDeaths, cs.newDeaths AS newDeaths
+	ORDER BY cs.date DESC
+	LIMIT 1
+	`, dateCondition("cs.date", mode))
 
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		rec, err := tx.Run(ctx, query, map[string]interface{}{
@@ -46,81 +52,94 @@ func (r *Neo4jCovidStatsRepository) GetTotalCasesAndDeaths(ctx context.Context,
 		}
 
 		if rec.Next(ctx) {
+			reportedOn, _ := rec.Record().Get("date")
 			cumulativeCases, _ := rec.Record().Get("cumulativeCases")
 			newCases, _ := rec.Record().Get("newCases")
 			cumulativeDeaths, _ := rec.Record().Get("cumulativeDeaths")
 			newDeaths, _ := rec.Record().Get("newDeaths")
 
-			return valueobjects.NewCovidStats(
-				int(cumulativeDeaths.(int64)),
-				int(newDeaths.(int64)),
-				int(cumulativeCases.(int64)),
-				int(newCases.(int64)),
-			), nil
+			stats := valueobjects.NewCovidStats(
+				nullableInt(cumulativeDeaths),
+				nullableInt(newDeaths),
+				nullableInt(cumulativeCases),
+				nullableInt(newCases),
+			)
+			if reportedOn := nullableDate(reportedOn); reportedOn != nil {
+				stats.ReportedOn = *reportedOn
+			}
+			return &stats, nil
 		}
 
 		if err = rec.Err(); err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("no COVID-19 statistics found for country %s on date %s", countryCode, date.Format(dateFormat))
+		return nil, domain.NotFoundf("no COVID-19 statistics found for country %s on date %s", countryCode, date.Format(dateFormat))
 	}))
 
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 
 	return result.(*valueobjects.CovidStats), nil
 }
 
-func (r *Neo4jCovidStatsRepository) GetCountryWithMostCases(ctx context.Context, date time.Time) (string, int, error) {
+// GetCovidSeries returns every report of countryCode between from and to,
+// inclusive and ordered by date. A zero from or to leaves that end open.
+func (r *Neo4jCovidStatsRepository) GetCovidSeries(ctx context.Context, countryCode string, from, to time.Time) ([]valueobjects.CovidStats, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeRead,
 	})
 	defer session.Close(ctx)
 
 	query := `
-	MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date {date: $date})
-	RETURN c.code AS countryCode, cs.cumulativeCases AS cumulativeCases
-	ORDER BY cs.cumulativeCases DESC
-	LIMIT 1
+	MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)
+	WHERE ($from IS NULL OR cs.date >= date($from)) AND ($to IS NULL OR cs.date <= date($to))
+	RETURN cs.date AS date, cs.cumulativeCases AS cumulativeCases, cs.newCases AS newCases, cs.cumulativeDeaths AS cumulativeDeaths, cs.newDeaths AS newDeaths
+	ORDER BY cs.date
 	`
 
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		rec, err := tx.Run(ctx, query, map[string]interface{}{
-			"date": date.Format(dateFormat),
+			"countryCode": countryCode,
+			"from":        nullableDateParam(from),
+			"to":          nullableDateParam(to),
 		})
 		if err != nil {
 			return nil, err
 		}
 
-		if rec.Next(ctx) {
-			countryCode, _ := rec.Record().Get("countryCode")
-			cumulativeCases, _ := rec.Record().Get("cumulativeCases")
-			return struct {
-				Code  string
-				Cases int
-			}{
-				Code:  countryCode.(string),
-				Cases: int(cumulativeCases.(int64)),
-			}, nil
+		series := []valueobjects.CovidStats{}
+		for rec.Next(ctx) {
+			record := rec.Record()
+			reportedOn, _ := record.Get("date")
+			cumulativeCases, _ := record.Get("cumulativeCases")
+			newCases, _ := record.Get("newCases")
+			cumulativeDeaths, _ := record.Get("cumulativeDeaths")
+			newDeaths, _ := record.Get("newDeaths")
+
+			stats := valueobjects.NewCovidStats(
+				nullableInt(cumulativeDeaths),
+				nullableInt(newDeaths),
+				nullableInt(cumulativeCases),
+				nullableInt(newCases),
+			)
+			if reportedOn := nullableDate(reportedOn); reportedOn != nil {
+				stats.ReportedOn = *reportedOn
+			}
+			series = append(series, stats)
 		}
 
 		if err = rec.Err(); err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("no COVID-19 statistics found for date %s", date.Format(dateFormat))
+		return series, nil
 	}))
 
 	if err != nil {
-		return "", 0, err
+		return nil, queryError(err)
 	}
 
-	res := result.(struct {
-		Code  string
-		Cases int
-	})
-
-	return res.Code, res.Cases, nil
+	return result.([]valueobjects.CovidStats), nil
 }
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// queryError returns the error of a transaction as the domain sees it:
+// failing to reach Neo4j, including after the driver gave up retrying or the
+// request ran out of time, becomes domain.ErrUpstreamUnavailable. Other
+// errors are returned as they are.
+func queryError(err error) error {
+	var connectivityErr *neo4j.ConnectivityError
+	var limitErr *neo4j.TransactionExecutionLimit
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &connectivityErr), errors.As(err, &limitErr),
+		errors.Is(err, context.DeadlineExceeded), neo4j.IsRetryable(err):
+		return domain.Unavailable("Neo4j", err)
+	default:
+		return err
+	}
+}
+
+// corruptRecord wraps the error an entity constructor returned for a record
+// read from Neo4j. The stored data is malformed, not the request, so the
+// error drops the invalid input kind and is answered as an internal error.
+func corruptRecord(entity string, err error) error {
+	return fmt.Errorf("corrupt %s record: %v", entity, err)
+}
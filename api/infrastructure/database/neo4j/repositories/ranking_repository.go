@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// metricSource locates a ranking metric in the graph: the stats nodes that
+// hold it, how countries reach them, and the property.
+type metricSource struct {
+	relationship string
+	label        string
+	property     string
+}
+
+var metricSources = map[valueobjects.RankingMetric]metricSource{
+	valueobjects.MetricCumulativeCases:              {"REPORTED_ON", "CovidStats", "cumulativeCases"},
+	valueobjects.MetricCumulativeDeaths:             {"REPORTED_ON", "CovidStats", "cumulativeDeaths"},
+	valueobjects.MetricNewCases:                     {"REPORTED_ON", "CovidStats", "newCases"},
+	valueobjects.MetricNewDeaths:                    {"REPORTED_ON", "CovidStats", "newDeaths"},
+	valueobjects.MetricTotalVaccinations:            {"VACCINATED_ON", "VaccinationStats", "totalVaccinations"},
+	valueobjects.MetricTotalVaccinationsPer100:      {"VACCINATED_ON", "VaccinationStats", "totalVaccinationsPer100"},
+	valueobjects.MetricPersonsVaccinated:            {"VACCINATED_ON", "VaccinationStats", "personsVaccinated1PlusDose"},
+	valueobjects.MetricPersonsVaccinatedPer100:      {"VACCINATED_ON", "VaccinationStats", "personsVaccinated1PlusDosePer100"},
+	valueobjects.MetricPersonsFullyVaccinated:       {"VACCINATED_ON", "VaccinationStats", "personsFullyVaccinated"},
+	valueobjects.MetricPersonsFullyVaccinatedPer100: {"VACCINATED_ON", "VaccinationStats", "personsFullyVaccinatedPer100"},
+	valueobjects.MetricPersonsBooster:               {"VACCINATED_ON", "VaccinationStats", "personsBoosterAdditionalDose"},
+	valueobjects.MetricPersonsBoosterPer100:         {"VACCINATED_ON", "VaccinationStats", "personsBoosterAdditionalDosePer100"},
+}
+
+type Neo4jRankingRepository struct {
+	driver neo4j.DriverWithContext
+}
+
+func NewNeo4jRankingRepository(driver neo4j.DriverWithContext) repositories.RankingRepository {
+	return &Neo4jRankingRepository{
+		driver: driver,
+	}
+}
+
+// GetRanking ranks countries by the metric in their report on the query
+// date, or with DateModeAsOf in their latest report on or before it that has
+// the metric. A country in several regions takes one rank position, with the
+// region it was filtered by or else the first by name.
+func (r *Neo4jRankingRepository) GetRanking(ctx context.Context, query valueobjects.RankingQuery) ([]valueobjects.RankingEntry, error) {
+	source, ok := metricSources[query.Metric]
+	if !ok {
+		return nil, domain.InvalidInputf("unknown ranking metric %q", query.Metric)
+	}
+
+	order := "DESC"
+	if query.Order == valueobjects.SortAscending {
+		order = "ASC"
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	cypher := fmt.Sprintf(`
+	MATCH (c:Country)-[:%s]->(s:%s)
+	WHERE %s AND s.%s IS NOT NULL
+	  AND ($region IS NULL OR EXISTS { (c)-[:BELONGS]->(:Region {name: $region}) })
+	WITH c, s
+	ORDER BY s.date DESC
+	WITH c, head(collect(s)) AS s
+	OPTIONAL MATCH (c)-[:BELONGS]->(r:Region)
+	WITH c, s, coalesce($region, min(r.name)) AS region
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric,
+		   region, s.date AS date, s.%s AS value
+	ORDER BY value %s, name ASC
+	LIMIT $limit
+	`, source.relationship, source.label, dateCondition("s.date", query.Mode), source.property, source.property, order)
+
+	var region interface{}
+	if query.Region != "" {
+		region = query.Region
+	}
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, cypher, map[string]interface{}{
+			"date":   query.Date.Format(dateFormat),
+			"region": region,
+			"limit":  query.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		entries := []valueobjects.RankingEntry{}
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, err
+			}
+
+			value, _ := record.Get("value")
+			reportedOn, _ := record.Get("date")
+			regionName, _, _ := neo4j.GetRecordValue[string](record, "region")
+
+			entry := valueobjects.RankingEntry{
+				Country: *country,
+				Region:  regionName,
+			}
+			if v := nullableFloat(value); v != nil {
+				entry.Value = *v
+			}
+			if reportedOn := nullableDate(reportedOn); reportedOn != nil {
+				entry.ReportedOn = *reportedOn
+			}
+			entries = append(entries, entry)
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return entries, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.RankingEntry), nil
+}
@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/domain/entities"
+	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type Neo4jRegionRepository struct {
+	driver neo4j.DriverWithContext
+}
+
+func NewNeo4jRegionRepository(driver neo4j.DriverWithContext) repositories.RegionRepository {
+	return &Neo4jRegionRepository{
+		driver: driver,
+	}
+}
+
+func (r *Neo4jRegionRepository) ListRegions(ctx context.Context) ([]valueobjects.RegionSummary, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+	MATCH (r:Region)
+	OPTIONAL MATCH (c:Country)-[:BELONGS]->(r)
+	RETURN r.name AS name, count(c) AS countries
+	ORDER BY name
+	`
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		regions := []valueobjects.RegionSummary{}
+		for rec.Next(ctx) {
+			name, _, _ := neo4j.GetRecordValue[string](rec.Record(), "name")
+			countries, _, _ := neo4j.GetRecordValue[int64](rec.Record(), "countries")
+
+			region, err := entities.NewRegion(name)
+			if err != nil {
+				return nil, corruptRecord("region", err)
+			}
+
+			regions = append(regions, valueobjects.RegionSummary{
+				Region:    *region,
+				Countries: int(countries),
+			})
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return regions, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.RegionSummary), nil
+}
+
+func (r *Neo4jRegionRepository) GetRegionCountries(ctx context.Context, regionName string) ([]entities.Country, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+	MATCH (c:Country)-[:BELONGS]->(:Region {name: $regionName})
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric
+	ORDER BY name
+	`
+
+	regionName = strings.TrimSpace(regionName)
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, map[string]interface{}{
+			"regionName": regionName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		countries := []entities.Country{}
+		for rec.Next(ctx) {
+			country, err := countryFromRecord(rec.Record())
+			if err != nil {
+				return nil, err
+			}
+			countries = append(countries, *country)
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(countries) == 0 {
+			return nil, domain.NotFoundf("region %s not found", regionName)
+		}
+
+		return countries, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]entities.Country), nil
+}
+
+func (r *Neo4jRegionRepository) GetCountryRegion(ctx context.Context, countryCode string) (*entities.Region, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+	MATCH (:Country {code: $countryCode})-[:BELONGS]->(r:Region)
+	RETURN r.name AS name
+	LIMIT 1
+	`
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, map[string]interface{}{
+			"countryCode": countryCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if rec.Next(ctx) {
+			name, _, _ := neo4j.GetRecordValue[string](rec.Record(), "name")
+			region, err := entities.NewRegion(name)
+			if err != nil {
+				return nil, corruptRecord("region", err)
+			}
+			return region, nil
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, domain.NotFoundf("no region found for country %s", countryCode)
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.(*entities.Region), nil
+}
+
+func (r *Neo4jRegionRepository) GetRegionCovidStats(ctx context.Context, regionName string, date time.Time, mode valueobjects.DateMode) ([]valueobjects.CountryCovidStats, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := fmt.Sprintf(`
+	MATCH (c:Country)-[:BELONGS]->(:Region {name: $regionName})
+	OPTIONAL MATCH (c)-[:REPORTED_ON]->(cs:CovidStats)
+	WHERE %s
+	WITH c, cs
+	ORDER BY cs.date DESC
+	WITH c, head(collect(cs)) AS cs
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric,
+		   cs IS NOT NULL AS reported, cs.date AS date, cs.cumulativeCases AS cumulativeCases, cs.newCases AS newCases,
+		   cs.cumulativeDeaths AS cumulativeDeaths, cs.newDeaths AS newDeaths
+	ORDER BY name
+	`, dateCondition("cs.date", mode))
+
+	regionName = strings.TrimSpace(regionName)
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, map[string]interface{}{
+			"regionName": regionName,
+			"date":       date.Format(dateFormat),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		countries := []valueobjects.CountryCovidStats{}
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, err
+			}
+			entry := valueobjects.CountryCovidStats{Country: *country}
+
+			if reported, _, _ := neo4j.GetRecordValue[bool](record, "reported"); reported {
+				reportedOn, _ := record.Get("date")
+				cumulativeCases, _ := record.Get("cumulativeCases")
+				newCases, _ := record.Get("newCases")
+				cumulativeDeaths, _ := record.Get("cumulativeDeaths")
+				newDeaths, _ := record.Get("newDeaths")
+
+				stats := valueobjects.NewCovidStats(
+					nullableInt(cumulativeDeaths),
+					nullableInt(newDeaths),
+					nullableInt(cumulativeCases),
+					nullableInt(newCases),
+				)
+				if reportedOn := nullableDate(reportedOn); reportedOn != nil {
+					stats.ReportedOn = *reportedOn
+				}
+				entry.Stats = &stats
+			}
+
+			countries = append(countries, entry)
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(countries) == 0 {
+			return nil, domain.NotFoundf("region %s not found", regionName)
+		}
+
+		return countries, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.CountryCovidStats), nil
+}
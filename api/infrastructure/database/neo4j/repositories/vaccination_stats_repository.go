@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
 	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 
@@ -21,19 +22,29 @@ func NewNeo4jVaccinationStatsRepository(driver neo4j.DriverWithContext) reposito
 	}
 }
 
-func (r *Neo4jVaccinationStatsRepository) GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time) (*valueobjects.VaccinationStats, error) {
+// GetVaccinatedPeople returns the vaccination report of countryCode updated
+// on date, or with DateModeAsOf its latest report updated on or before date.
+func (r *Neo4jVaccinationStatsRepository) GetVaccinatedPeople(ctx context.Context, countryCode string, date time.Time, mode valueobjects.DateMode) (*valueobjects.VaccinationStats, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeRead,
 	})
 	defer session.Close(ctx)
 
-	query := `
-	MATCH (c:Country {code: $countryCode})-[:VACCINATED_ON]->(vs:VaccinationStats)-[:ON_DATE]->(d:Date {date: $date})
-	RETURN vs.personsVaccinated1PlusDose AS personsVaccinated1PlusDose,
+	query := fmt.Sprintf(`
+	MATCH (c:Country {code: $countryCode})-[:VACCINATED_ON]->(vs:VaccinationStats)
+	WHERE %s
+	RETURN vs.date AS date,
+		   vs.personsVaccinated1PlusDose AS personsVaccinated1PlusDose,
 		   vs.personsVaccinated1PlusDosePer100 AS personsVaccinated1PlusDosePer100,
 		   vs.totalVaccinations AS totalVaccinations,
-		   vs.totalVaccinationsPer100 AS totalVaccinationsPer100
-	`
+		   vs.totalVaccinationsPer100 AS totalVaccinationsPer100,
+		   vs.personsFullyVaccinated AS personsLastDose,
+		   vs.personsFullyVaccinatedPer100 AS personsLastDosePer100,
+		   vs.personsBoosterAdditionalDose AS personsBoosterAddDose,
+		   vs.personsBoosterAdditionalDosePer100 AS personsBoosterAddDosePer100
+	ORDER BY vs.date DESC
+	LIMIT 1
+	`, dateCondition("vs.date", mode))
 
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		rec, err := tx.Run(ctx, query, map[string]interface{}{
@@ -45,32 +56,41 @@ func (r *Neo4jVaccinationStatsRepository) GetVaccinatedPeople(ctx context.Contex
 		}
 
 		if rec.Next(ctx) {
+			reportedOn, _ := rec.Record().Get("date")
 			personsVaccinated1PlusDose, _ := rec.Record().Get("personsVaccinated1PlusDose")
 			personsVaccinated1PlusDosePer100, _ := rec.Record().Get("personsVaccinated1PlusDosePer100")
 			totalVaccinations, _ := rec.Record().Get("totalVaccinations")
 			totalVaccinationsPer100, _ := rec.Record().Get("totalVaccinationsPer100")
+			personsLastDose, _ := rec.Record().Get("personsLastDose")
+			personsLastDosePer100, _ := rec.Record().Get("personsLastDosePer100")
+			personsBoosterAddDose, _ := rec.Record().Get("personsBoosterAddDose")
+			personsBoosterAddDosePer100, _ := rec.Record().Get("personsBoosterAddDosePer100")
 
-			return valueobjects.NewVaccinationStats(
-				int(personsVaccinated1PlusDosePer100.(int64)),
-				int(personsVaccinated1PlusDose.(int64)),
-				0, // Placeholder if not available
-				0, // Placeholder if not available
-				int(personsVaccinated1PlusDosePer100.(int64)),
-				int(totalVaccinationsPer100.(int64)),
-				int(personsVaccinated1PlusDose.(int64)),
-				int(totalVaccinations.(int64)),
-			), nil
+			stats := valueobjects.NewVaccinationStats(
+				nullableFloat(personsBoosterAddDosePer100),
+				nullableInt(personsBoosterAddDose),
+				nullableFloat(personsLastDosePer100),
+				nullableInt(personsLastDose),
+				nullableFloat(personsVaccinated1PlusDosePer100),
+				nullableFloat(totalVaccinationsPer100),
+				nullableInt(personsVaccinated1PlusDose),
+				nullableInt(totalVaccinations),
+			)
+			if reportedOn := nullableDate(reportedOn); reportedOn != nil {
+				stats.ReportedOn = *reportedOn
+			}
+			return &stats, nil
 		}
 
 		if err = rec.Err(); err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("no vaccination statistics found for country %s on date %s", countryCode, date.Format("2006-01-02"))
+		return nil, domain.NotFoundf("no vaccination statistics found for country %s on date %s", countryCode, date.Format("2006-01-02"))
 	}))
 
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 
 	return result.(*valueobjects.VaccinationStats), nil
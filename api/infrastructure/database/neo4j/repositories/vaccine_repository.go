@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"strings"
 	"time"
 
+	"github.com/thalesmacedo1/covid-api/domain"
 	"github.com/thalesmacedo1/covid-api/domain/entities"
 	"github.com/thalesmacedo1/covid-api/domain/repositories"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
@@ -23,8 +25,9 @@ func NewNeo4jVaccineRepository(driver neo4j.DriverWithContext) repositories.Vacc
 
 func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCode string) ([]struct {
 	Vaccine           entities.Vaccine
-	StartDate         time.Time
-	AuthorizationDate time.Time
+	StartDate         *time.Time
+	AuthorizationDate *time.Time
+	EndDate           *time.Time
 }, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeRead,
@@ -32,10 +35,10 @@ func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCod
 	defer session.Close(ctx)
 
 	query := `
-	MATCH (c:Country {code: $countryCode})-[:USES]->(v:Vaccine)
-	OPTIONAL MATCH (v)-[:STARTED_ON]->(d:Date)
-	OPTIONAL MATCH (v)-[:AUTHORIZATION_ON]->(ad:Date)
-	RETURN v.company AS company, v.vaccine AS vaccine, v.product AS product, d.date AS startDate, ad.date AS authorizationDate
+	MATCH (c:Country {code: $countryCode})-[u:USES]->(v:Vaccine)
+	RETURN v.company AS company, v.vaccine AS vaccine, v.product AS product,
+		   v.platform AS platform, v.doses AS doses, v.whoEul AS whoEul,
+		   u.startDate AS startDate, u.authorizationDate AS authorizationDate, u.endDate AS endDate
 	`
 
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
@@ -48,8 +51,9 @@ func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCod
 
 		var vaccines []struct {
 			Vaccine           entities.Vaccine
-			StartDate         time.Time
-			AuthorizationDate time.Time
+			StartDate         *time.Time
+			AuthorizationDate *time.Time
+			EndDate           *time.Time
 		}
 
 		for rec.Next(ctx) {
@@ -58,8 +62,9 @@ func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCod
 			companyInterface, _ := record.Get("company")
 			vaccineNameInterface, _ := record.Get("vaccine")
 			productInterface, _ := record.Get("product")
-			startDateStr, _ := record.Get("startDate")
-			authorizationDateStr, _ := record.Get("authorizationDate")
+			startDateValue, _ := record.Get("startDate")
+			authorizationDateValue, _ := record.Get("authorizationDate")
+			endDateValue, _ := record.Get("endDate")
 
 			// Check and convert company
 			company, ok := companyInterface.(string)
@@ -79,38 +84,27 @@ func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCod
 				product = "Unknown Product"
 			}
 
-			// Parse start date
-			var startDate time.Time
-			if startDateStr != nil {
-				if dateStr, ok := startDateStr.(string); ok {
-					parsedDate, err := time.Parse("2006-01-02", dateStr)
-					if err == nil {
-						startDate = parsedDate
-					}
-				}
-			}
-
-			// Parse authorization date
-			var authorizationDate time.Time
-			if authorizationDateStr != nil {
-				if dateStr, ok := authorizationDateStr.(string); ok {
-					parsedDate, err := time.Parse("2006-01-02", dateStr)
-					if err == nil {
-						authorizationDate = parsedDate
-					}
-				}
-			}
+			// The dates are the ones this country reported, kept on its USES relationship
+			startDate := nullableDate(startDateValue)
+			authorizationDate := nullableDate(authorizationDateValue)
+			endDate := nullableDate(endDateValue)
 
 			vaccine := entities.NewVaccine(vaccineName, company, product)
+			platformValue, _ := record.Get("platform")
+			dosesValue, _ := record.Get("doses")
+			whoEULValue, _ := record.Get("whoEul")
+			withPlatform(vaccine, platformValue, dosesValue, whoEULValue)
 
 			vaccines = append(vaccines, struct {
 				Vaccine           entities.Vaccine
-				StartDate         time.Time
-				AuthorizationDate time.Time
+				StartDate         *time.Time
+				AuthorizationDate *time.Time
+				EndDate           *time.Time
 			}{
 				Vaccine:           *vaccine,
 				StartDate:         startDate,
 				AuthorizationDate: authorizationDate,
+				EndDate:           endDate,
 			})
 		}
 
@@ -122,29 +116,45 @@ func (r *Neo4jVaccineRepository) GetVaccinesUsed(ctx context.Context, countryCod
 	}))
 
 	if err != nil {
-		return nil, err
+		return nil, queryError(err)
 	}
 
 	return result.([]struct {
 		Vaccine           entities.Vaccine
-		StartDate         time.Time
-		AuthorizationDate time.Time
+		StartDate         *time.Time
+		AuthorizationDate *time.Time
+		EndDate           *time.Time
 	}), nil
 }
 
-func (r *Neo4jVaccineRepository) GetMostUsedVaccine(ctx context.Context, regionName string) (*entities.Vaccine, int, error) {
+// GetRegionVaccineUsage returns every country of the region with the
+// vaccines it uses and the total of doses in its latest vaccination report
+// that has one.
+func (r *Neo4jVaccineRepository) GetRegionVaccineUsage(ctx context.Context, regionName string) ([]valueobjects.CountryVaccineUsage, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeRead,
 	})
 	defer session.Close(ctx)
 
 	query := `
-	MATCH (r:Region {name: $regionName})<-[:BELONGS]-(c:Country)-[:USES]->(v:Vaccine)
-	RETURN v.name AS vaccineName, v.company AS company, v.product AS product, COUNT(v) AS usageCount
-	ORDER BY usageCount DESC
-	LIMIT 1
+	MATCH (c:Country)-[:BELONGS]->(:Region {name: $regionName})
+	OPTIONAL MATCH (c)-[:USES]->(v:Vaccine)
+	WITH c, collect(v {.product, .company, .vaccine, .platform, .doses, .whoEul}) AS vaccines
+	CALL {
+		WITH c
+		OPTIONAL MATCH (c)-[:VACCINATED_ON]->(vs:VaccinationStats)
+		WHERE vs.totalVaccinations IS NOT NULL
+		RETURN vs
+		ORDER BY vs.date DESC
+		LIMIT 1
+	}
+	RETURN c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric,
+		   vaccines, vs.totalVaccinations AS doses, vs.date AS dosesReportedOn
+	ORDER BY code
 	`
 
+	regionName = strings.TrimSpace(regionName)
+
 	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		rec, err := tx.Run(ctx, query, map[string]interface{}{
 			"regionName": regionName,
@@ -153,63 +163,244 @@ func (r *Neo4jVaccineRepository) GetMostUsedVaccine(ctx context.Context, regionN
 			return nil, err
 		}
 
-		if rec.Next(ctx) {
+		usage := []valueobjects.CountryVaccineUsage{}
+		for rec.Next(ctx) {
 			record := rec.Record()
-			vaccineNameInterface, _ := record.Get("vaccineName")
-			companyInterface, _ := record.Get("company")
-			productInterface, _ := record.Get("product")
-			usageCountInterface, _ := record.Get("usageCount")
 
-			// Check and convert vaccineName
-			vaccineName, ok := vaccineNameInterface.(string)
-			if !ok || vaccineName == "" {
-				return nil, fmt.Errorf("vaccine name is missing or invalid")
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, err
 			}
 
-			// Check and convert company
-			company, ok := companyInterface.(string)
-			if !ok || company == "" {
-				company = "Unknown Company"
+			vaccinesValue, _ := record.Get("vaccines")
+			dosesValue, _ := record.Get("doses")
+			dosesReportedOnValue, _ := record.Get("dosesReportedOn")
+
+			usage = append(usage, valueobjects.CountryVaccineUsage{
+				Country:         *country,
+				Vaccines:        vaccinesFromValue(vaccinesValue),
+				Doses:           nullableInt(dosesValue),
+				DosesReportedOn: nullableDate(dosesReportedOnValue),
+			})
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(usage) == 0 {
+			return nil, domain.NotFoundf("region %s not found", regionName)
+		}
+
+		return usage, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.CountryVaccineUsage), nil
+}
+
+// ListVaccines returns every vaccine with the number of countries that use
+// it. The product the API exposes is the vaccine name of the graph.
+func (r *Neo4jVaccineRepository) ListVaccines(ctx context.Context) ([]valueobjects.VaccineSummary, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+	MATCH (v:Vaccine)
+	WHERE v.vaccine IS NOT NULL
+	OPTIONAL MATCH (v)<-[:USES]-(c:Country)
+	RETURN v.vaccine AS vaccine, v.company AS company, v.product AS product,
+		   v.platform AS platform, v.doses AS doses, v.whoEul AS whoEul, count(c) AS countries
+	ORDER BY vaccine
+	`
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		rec, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		vaccines := []valueobjects.VaccineSummary{}
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			vaccineName, _, _ := neo4j.GetRecordValue[string](record, "vaccine")
+			company, _, _ := neo4j.GetRecordValue[string](record, "company")
+			product, _, _ := neo4j.GetRecordValue[string](record, "product")
+			countries, _, _ := neo4j.GetRecordValue[int64](record, "countries")
+			platformValue, _ := record.Get("platform")
+			dosesValue, _ := record.Get("doses")
+			whoEULValue, _ := record.Get("whoEul")
+
+			vaccines = append(vaccines, valueobjects.VaccineSummary{
+				Vaccine:   *withPlatform(entities.NewVaccine(vaccineName, company, product), platformValue, dosesValue, whoEULValue),
+				Countries: int(countries),
+			})
+		}
+
+		if err = rec.Err(); err != nil {
+			return nil, err
+		}
+
+		return vaccines, nil
+	}))
+
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	return result.([]valueobjects.VaccineSummary), nil
+}
+
+// GetVaccineCountries returns the vaccine whose vaccine name is product,
+// reading its USES relationships in reverse to find the countries that use
+// it and the dates each reported. A country in several regions is listed
+// once, with the region it was filtered by or else the first by name.
+func (r *Neo4jVaccineRepository) GetVaccineCountries(ctx context.Context, product string, regionName string) (*valueobjects.VaccineAdoption, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode: neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	regionQuery := `
+	RETURN EXISTS { (:Country)-[:BELONGS]->(:Region {name: $region}) } AS known
+	`
+
+	query := `
+	MATCH (v:Vaccine {vaccine: $product})
+	OPTIONAL MATCH (v)<-[u:USES]-(c:Country)
+	WHERE $region IS NULL OR EXISTS { (c)-[:BELONGS]->(:Region {name: $region}) }
+	OPTIONAL MATCH (c)-[:BELONGS]->(r:Region)
+	WITH v, u, c, coalesce($region, min(r.name)) AS region
+	RETURN v.vaccine AS vaccine, v.company AS company, v.product AS product,
+		   v.platform AS platform, v.doses AS doses, v.whoEul AS whoEul,
+		   c.name AS name, c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric, region,
+		   u.startDate AS startDate, u.authorizationDate AS authorizationDate, u.endDate AS endDate
+	ORDER BY code
+	`
+
+	product = strings.TrimSpace(product)
+	var region interface{}
+	if regionName = strings.TrimSpace(regionName); regionName != "" {
+		region = regionName
+	}
+
+	params := map[string]interface{}{
+		"product": product,
+		"region":  region,
+	}
+
+	result, err := session.ExecuteRead(ctx, neo4j.ManagedTransactionWork(func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		if region != nil {
+			rec, err := tx.Run(ctx, regionQuery, params)
+			if err != nil {
+				return nil, err
+			}
+			record, err := rec.Single(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if known, _, _ := neo4j.GetRecordValue[bool](record, "known"); !known {
+				return nil, domain.NotFoundf("region %s not found", regionName)
 			}
+		}
 
-			// Check and convert product
-			product, ok := productInterface.(string)
-			if !ok || product == "" {
-				product = "Unknown Product"
+		rec, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var adoption *valueobjects.VaccineAdoption
+		for rec.Next(ctx) {
+			record := rec.Record()
+
+			if adoption == nil {
+				vaccineName, _, _ := neo4j.GetRecordValue[string](record, "vaccine")
+				company, _, _ := neo4j.GetRecordValue[string](record, "company")
+				productName, _, _ := neo4j.GetRecordValue[string](record, "product")
+				platformValue, _ := record.Get("platform")
+				dosesValue, _ := record.Get("doses")
+				whoEULValue, _ := record.Get("whoEul")
+				adoption = &valueobjects.VaccineAdoption{
+					Vaccine:   *withPlatform(entities.NewVaccine(vaccineName, company, productName), platformValue, dosesValue, whoEULValue),
+					Countries: []valueobjects.VaccineAdopter{},
+				}
 			}
 
-			// Convert usageCount
-			usageCount, ok := usageCountInterface.(int64)
-			if !ok {
-				return nil, fmt.Errorf("usage count is missing or invalid")
+			// A vaccine no country of the query uses comes back as a single row without one
+			if code, _ := record.Get("code"); code == nil {
+				continue
 			}
 
-			vaccine := entities.NewVaccine(vaccineName, company, product)
+			country, err := countryFromRecord(record)
+			if err != nil {
+				return nil, err
+			}
 
-			return struct {
-				Vaccine    entities.Vaccine
-				UsageCount int
-			}{
-				Vaccine:    *vaccine,
-				UsageCount: int(usageCount),
-			}, nil
+			regionValue, _ := record.Get("region")
+			countryRegion, _ := regionValue.(string)
+			startDateValue, _ := record.Get("startDate")
+			authorizationDateValue, _ := record.Get("authorizationDate")
+			endDateValue, _ := record.Get("endDate")
+
+			adoption.Countries = append(adoption.Countries, valueobjects.VaccineAdopter{
+				Country:           *country,
+				Region:            countryRegion,
+				StartDate:         nullableDate(startDateValue),
+				AuthorizationDate: nullableDate(authorizationDateValue),
+				EndDate:           nullableDate(endDateValue),
+			})
 		}
 
 		if err = rec.Err(); err != nil {
 			return nil, err
 		}
 
-		return nil, fmt.Errorf("no vaccines found for region %s", regionName)
+		if adoption == nil {
+			return nil, domain.NotFoundf("vaccine %s not found", product)
+		}
+
+		return adoption, nil
 	}))
 
 	if err != nil {
-		return nil, 0, err
+		return nil, queryError(err)
 	}
 
-	res := result.(struct {
-		Vaccine    entities.Vaccine
-		UsageCount int
-	})
+	return result.(*valueobjects.VaccineAdoption), nil
+}
+
+// vaccinesFromValue reads a list of vaccine map projections, skipping the
+// ones without a vaccine name.
+func vaccinesFromValue(value interface{}) []entities.Vaccine {
+	items, _ := value.([]interface{})
+
+	vaccines := make([]entities.Vaccine, 0, len(items))
+	for _, item := range items {
+		props, _ := item.(map[string]interface{})
+		vaccineName, _ := props["vaccine"].(string)
+		if vaccineName == "" {
+			continue
+		}
+		company, _ := props["company"].(string)
+		product, _ := props["product"].(string)
+		// Like GetVaccinesUsed, the API calls the full vaccine name the product
+		vaccine := entities.NewVaccine(vaccineName, company, product)
+		vaccines = append(vaccines, *withPlatform(vaccine, props["platform"], props["doses"], props["whoEul"]))
+	}
+	return vaccines
+}
 
-	return &res.Vaccine, res.UsageCount, nil
+// withPlatform sets the properties the ETL enriches vaccine with from the
+// platform mapping, leaving the ones its node lacks empty.
+func withPlatform(vaccine *entities.Vaccine, platform, doses, whoEUL interface{}) *entities.Vaccine {
+	vaccine.Platform, _ = platform.(string)
+	vaccine.Doses = nullableInt(doses)
+	vaccine.WHOEUL, _ = whoEUL.(string)
+	return vaccine
 }
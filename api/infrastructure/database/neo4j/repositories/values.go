@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// dateCondition matches the date property against the $date parameter: the
+// same day for DateModeExact, any day up to it for DateModeAsOf. Queries using
+// it in as-of mode order by the property to pick the latest report.
+func dateCondition(property string, mode valueobjects.DateMode) string {
+	if mode == valueobjects.DateModeExact {
+		return property + " = date($date)"
+	}
+	return property + " <= date($date)"
+}
+
+// nullableDateParam formats t for date() in a query, or returns nil when t is
+// the zero time.
+func nullableDateParam(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.Format(dateFormat)
+}
+
+// nullableInt converts an integer property read from Neo4j, returning nil
+// when the property is absent.
+func nullableInt(value interface{}) *int {
+	n, ok := value.(int64)
+	if !ok {
+		return nil
+	}
+	i := int(n)
+	return &i
+}
+
+// nullableFloat converts a decimal property read from Neo4j, returning nil
+// when the property is absent. Rates older loads stored as integers are
+// accepted too.
+func nullableFloat(value interface{}) *float64 {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case int64:
+		f = float64(v)
+	default:
+		return nil
+	}
+	return &f
+}
+
+// nullableDate converts a date property read from Neo4j, returning nil when
+// the property is absent. Dates stored as YYYY-MM-DD strings are accepted too.
+func nullableDate(value interface{}) *time.Time {
+	var t time.Time
+	switch v := value.(type) {
+	case dbtype.Date:
+		t = v.Time()
+	case time.Time:
+		t = v
+	case string:
+		parsed, err := time.Parse(dateFormat, v)
+		if err != nil {
+			return nil
+		}
+		t = parsed
+	default:
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type CompanyHandler struct {
+	GetCompaniesUC       usecases.GetCompaniesUseCase
+	GetRegionCompaniesUC usecases.GetRegionCompaniesUseCase
+	Logger               logger.Logger
+}
+
+func NewCompanyHandler(getCompaniesUC usecases.GetCompaniesUseCase, getRegionCompaniesUC usecases.GetRegionCompaniesUseCase, logger logger.Logger) *CompanyHandler {
+	return &CompanyHandler{
+		GetCompaniesUC:       getCompaniesUC,
+		GetRegionCompaniesUC: getRegionCompaniesUC,
+		Logger:               logger,
+	}
+}
+
+// GetCompanies godoc
+// @Summary List vaccine manufacturers
+// @Description Lists every manufacturer with the number of vaccines it produces and the share of countries that use at least one of them, most adopted first
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Success 200 {object} usecases.GetCompaniesOutput "Successful retrieval of manufacturers"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/companies [get]
+func (h *CompanyHandler) GetCompanies(c *gin.Context) {
+	output, err := h.GetCompaniesUC.Execute(c.Request.Context())
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetCompaniesUseCase", err, "Failed to retrieve companies.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetRegionCompanies godoc
+// @Summary Retrieve the manufacturers' market share in a region
+// @Description Lists the manufacturers whose vaccines the countries of a WHO region use, with the number of their vaccines used there and the share of the region's countries that use at least one, most adopted first
+// @Tags Company
+// @Accept json
+// @Produce json
+// @Param regionName path string true "WHO region (e.g., AMRO, EURO, SEARO)"
+// @Success 200 {object} usecases.GetRegionCompaniesOutput "Successful retrieval of the region's manufacturers"
+// @Failure 404 {object} Problem "Unknown region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions/{regionName}/companies [get]
+func (h *CompanyHandler) GetRegionCompanies(c *gin.Context) {
+	input := usecases.GetRegionCompaniesInput{
+		RegionName: c.Param("regionName"),
+	}
+
+	output, err := h.GetRegionCompaniesUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionCompaniesUseCase", err, "Failed to retrieve region companies.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
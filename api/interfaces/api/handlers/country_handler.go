@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+const (
+	defaultCountriesLimit = 50
+	maxCountriesLimit     = 250
+)
+
+type CountryHandler struct {
+	GetCountriesUC      usecases.GetCountriesUseCase
+	GetCountryProfileUC usecases.GetCountryProfileUseCase
+	Logger              logger.Logger
+}
+
+func NewCountryHandler(getCountriesUC usecases.GetCountriesUseCase, getCountryProfileUC usecases.GetCountryProfileUseCase, logger logger.Logger) *CountryHandler {
+	return &CountryHandler{
+		GetCountriesUC:      getCountriesUC,
+		GetCountryProfileUC: getCountryProfileUC,
+		Logger:              logger,
+	}
+}
+
+// GetCountries godoc
+// @Summary List countries
+// @Description Lists the countries with their ISO codes, region and the dates their COVID-19 and vaccination reports cover, one page at a time
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param region query string false "Only countries of this WHO region"
+// @Param name query string false "Search term matched against country names, ignoring case and accents"
+// @Param match query string false "contains (default) or fuzzy, which also tolerates typos" Enums(contains, fuzzy)
+// @Param has_vaccination_data query bool false "Only countries with (true) or without (false) vaccination reports"
+// @Param sort query string false "name (default), code or region" Enums(name, code, region)
+// @Param order query string false "asc (default) or desc" Enums(asc, desc)
+// @Param cursor query string false "NextCursor of the previous page"
+// @Param limit query int false "Countries per page, 50 by default and at most 250"
+// @Success 200 {object} usecases.GetCountriesOutput "Successful retrieval of countries"
+// @Failure 400 {object} Problem "Invalid match, has_vaccination_data, sort, order, cursor or limit"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/countries [get]
+func (h *CountryHandler) GetCountries(c *gin.Context) {
+	match, err := valueobjects.ParseNameMatch(c.Query("match"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidMatch, "Invalid match. Use contains or fuzzy.")
+		return
+	}
+
+	var hasVaccinationData *bool
+	if value := c.Query("has_vaccination_data"); value != "" {
+		has, err := strconv.ParseBool(value)
+		if err != nil {
+			writeProblem(c, http.StatusBadRequest, codeInvalidFilter, "Invalid has_vaccination_data. Use true or false.")
+			return
+		}
+		hasVaccinationData = &has
+	}
+
+	sort, err := valueobjects.ParseCountrySort(c.Query("sort"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidSort, "Invalid sort. Use name, code or region.")
+		return
+	}
+
+	// Unlike rankings, the catalogue reads in ascending order by default
+	order := valueobjects.SortAscending
+	if value := c.Query("order"); value != "" {
+		if order, err = valueobjects.ParseSortOrder(value); err != nil {
+			writeProblem(c, http.StatusBadRequest, codeInvalidOrder, "Invalid order. Use asc or desc.")
+			return
+		}
+	}
+
+	cursor, err := valueobjects.ParseCursor(c.Query("cursor"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidCursor, "Invalid cursor. Use the NextCursor of the previous page.")
+		return
+	}
+
+	limit := defaultCountriesLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxCountriesLimit {
+			writeProblem(c, http.StatusBadRequest, codeInvalidLimit, "Invalid limit. Use a number from 1 to 250.")
+			return
+		}
+	}
+
+	input := usecases.GetCountriesInput{
+		Region:             c.Query("region"),
+		Name:               c.Query("name"),
+		Match:              match,
+		HasVaccinationData: hasVaccinationData,
+		Sort:               sort,
+		Order:              order,
+		Cursor:             cursor,
+		Limit:              limit,
+	}
+
+	output, err := h.GetCountriesUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetCountriesUseCase", err, "Failed to retrieve countries.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetProfile godoc
+// @Summary Retrieve a country profile
+// @Description Fetches a country's identity, region, latest COVID-19 totals, latest vaccination coverage and vaccines as of a date, with the dates of the reports used
+// @Tags Country
+// @Accept json
+// @Produce json
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
+// @Param as_of query string false "Date in YYYY-MM-DD format, today by default" format(date)
+// @Success 200 {object} usecases.GetCountryProfileOutput "Successful retrieval of the country profile"
+// @Failure 400 {object} Problem "Invalid date format or country code"
+// @Failure 404 {object} Problem "Unknown country"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/countries/{countryCode}/profile [get]
+func (h *CountryHandler) GetProfile(c *gin.Context) {
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
+
+	asOf, ok := optionalDateQuery(c, "as_of")
+	if !ok {
+		return
+	}
+	if asOf.IsZero() {
+		asOf = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	input := usecases.GetCountryProfileInput{
+		CountryCode: countryCode,
+		AsOf:        asOf,
+	}
+
+	output, err := h.GetCountryProfileUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetCountryProfileUseCase", err, "Failed to retrieve country profile.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -6,19 +6,22 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
 )
 
 type CovidHandler struct {
 	GetCovidTotalsUC          usecases.GetCovidTotalsUseCase
 	getCountryWithMostCasesUC usecases.GetCountryWithMostCasesUseCase
+	getCovidSeriesUC          usecases.GetCovidSeriesUseCase
 	Logger                    logger.Logger
 }
 
-func NewCovidHandler(getCovidTotalsUC usecases.GetCovidTotalsUseCase, getCountryWithMostCasesUC usecases.GetCountryWithMostCasesUseCase, logger logger.Logger) *CovidHandler {
+func NewCovidHandler(getCovidTotalsUC usecases.GetCovidTotalsUseCase, getCountryWithMostCasesUC usecases.GetCountryWithMostCasesUseCase, getCovidSeriesUC usecases.GetCovidSeriesUseCase, logger logger.Logger) *CovidHandler {
 	return &CovidHandler{
 		GetCovidTotalsUC:          getCovidTotalsUC,
 		getCountryWithMostCasesUC: getCountryWithMostCasesUC,
+		getCovidSeriesUC:          getCovidSeriesUC,
 		Logger:                    logger,
 	}
 }
@@ -29,32 +32,43 @@ func NewCovidHandler(getCovidTotalsUC usecases.GetCovidTotalsUseCase, getCountry
 // @Tags Covid
 // @Accept json
 // @Produce json
-// @Param countryCode path string true "ISO Country Code"
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
 // @Param date path string true "Date in YYYY-MM-DD format" format(date)
+// @Param mode query string false "as_of (default) returns the latest report on or before the date, exact only a report on the date" Enums(as_of, exact)
 // @Success 200 {object} usecases.GetCovidTotalsOutput "Successful retrieval of COVID-19 totals"
-// @Failure 400 {object} map[string]string "Invalid date format"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} Problem "Invalid date format, mode or country code"
+// @Failure 404 {object} Problem "No COVID-19 statistics for the country and date"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
 // @Router /api/v1/countries/{countryCode}/covid/{date} [get]
 func (h *CovidHandler) GetTotals(c *gin.Context) {
-	countryCode := c.Param("countryCode")
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
 	dateStr := c.Param("date")
 
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
 		h.Logger.Warnf("Invalid date format: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD."})
+		writeProblem(c, http.StatusBadRequest, codeInvalidDate, "Invalid date format. Use YYYY-MM-DD.")
+		return
+	}
+
+	mode, ok := dateModeParam(c)
+	if !ok {
 		return
 	}
 
 	input := usecases.GetCovidTotalsInput{
 		CountryCode: countryCode,
 		Date:        date,
+		Mode:        mode,
 	}
 
 	output, err := h.GetCovidTotalsUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		h.Logger.Errorf("Error executing GetCovidTotalsUseCase: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve COVID totals."})
+		writeUseCaseError(c, h.Logger, "GetCovidTotalsUseCase", err, "Failed to retrieve COVID totals.")
 		return
 	}
 
@@ -67,30 +81,90 @@ func (h *CovidHandler) GetTotals(c *gin.Context) {
 // @Tags Covid
 // @Accept json
 // @Produce json
-// @Param date path string true "Date in YYYY-MM-DD format" format(date)
+// @Param date query string true "Date in YYYY-MM-DD format" format(date)
+// @Param mode query string false "as_of (default) returns the latest report on or before the date, exact only a report on the date" Enums(as_of, exact)
 // @Success 200 {object} usecases.CountryWithMostCasesOutput "Successful retrieval of country with most cases"
-// @Failure 400 {object} map[string]string "Invalid date format"
-// @Failure 500 {object} map[string]string "Internal server error"
-// @Router /api/v1/countries/highest-cases?{date} [get]
+// @Failure 400 {object} Problem "Invalid date format or mode"
+// @Failure 404 {object} Problem "No COVID-19 statistics for the date"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/countries/highest-cases [get]
 func (h *CovidHandler) GetCountryWithMostCases(c *gin.Context) {
-	dateStr := c.Param("date")
+	dateStr := c.Query("date")
 
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
 		h.Logger.Warnf("Invalid date format: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD."})
+		writeProblem(c, http.StatusBadRequest, codeInvalidDate, "Invalid date format. Use YYYY-MM-DD.")
+		return
+	}
+
+	mode, ok := dateModeParam(c)
+	if !ok {
 		return
 	}
 
 	input := usecases.GetCountryWithMostCasesInput{
 		Date: date,
+		Mode: mode,
 	}
 
 	country, err := h.getCountryWithMostCasesUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		h.Logger.Errorf("Error getting country with most cases: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get country with most cases"})
+		writeUseCaseError(c, h.Logger, "GetCountryWithMostCasesUseCase", err, "Failed to get country with most cases")
 		return
 	}
 	c.JSON(http.StatusOK, country)
 }
+
+// GetSeries godoc
+// @Summary Retrieve a COVID-19 time series
+// @Description Fetches the new and cumulative cases and deaths of a country over a date range, resampled by day, week or month
+// @Tags Covid
+// @Accept json
+// @Produce json
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
+// @Param from query string false "First date in YYYY-MM-DD format" format(date)
+// @Param to query string false "Last date in YYYY-MM-DD format" format(date)
+// @Param interval query string false "Resampling interval, day by default" Enums(day, week, month)
+// @Success 200 {object} usecases.GetCovidSeriesOutput "Successful retrieval of the COVID-19 series"
+// @Failure 400 {object} Problem "Invalid date range, interval or country code"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/countries/{countryCode}/covid [get]
+func (h *CovidHandler) GetSeries(c *gin.Context) {
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
+
+	from, ok := optionalDateQuery(c, "from")
+	if !ok {
+		return
+	}
+	to, ok := optionalDateQuery(c, "to")
+	if !ok {
+		return
+	}
+
+	interval, err := valueobjects.ParseInterval(c.Query("interval"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidInterval, "Invalid interval. Use day, week or month.")
+		return
+	}
+
+	input := usecases.GetCovidSeriesInput{
+		CountryCode: countryCode,
+		From:        from,
+		To:          to,
+		Interval:    interval,
+	}
+
+	output, err := h.getCovidSeriesUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetCovidSeriesUseCase", err, "Failed to retrieve COVID series.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-shared/iso3166"
+)
+
+// countryCodeParam resolves the :countryCode path parameter, given as an
+// ISO 3166 alpha-2, alpha-3 or numeric code, to the alpha-3 code countries
+// are stored under. It writes a 400 response and returns false when the code
+// is unknown.
+func countryCodeParam(c *gin.Context) (string, bool) {
+	code := c.Param("countryCode")
+
+	country, ok := iso3166.Lookup(code)
+	if !ok {
+		writeProblem(c, http.StatusBadRequest, codeInvalidCountryCode, "Unknown country code. Use an ISO 3166 alpha-2, alpha-3 or numeric code.")
+		return "", false
+	}
+
+	return country.Alpha3, true
+}
+
+// dateModeParam reads the mode query parameter: as_of (the default) answers
+// with the latest report on or before the requested date, exact only with a
+// report on that date. It writes a 400 response and returns false for any
+// other value.
+func dateModeParam(c *gin.Context) (valueobjects.DateMode, bool) {
+	mode, err := valueobjects.ParseDateMode(c.Query("mode"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidMode, "Invalid mode. Use as_of or exact.")
+		return "", false
+	}
+
+	return mode, true
+}
+
+// optionalDateQuery reads a YYYY-MM-DD query parameter, returning the zero
+// time when it is absent. It writes a 400 response and returns false when the
+// value is not a date.
+func optionalDateQuery(c *gin.Context, name string) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidDate, fmt.Sprintf("Invalid %s date format. Use YYYY-MM-DD.", name))
+		return time.Time{}, false
+	}
+
+	return date, true
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type PlatformHandler struct {
+	GetCountryVaccinePlatformsUC usecases.GetCountryVaccinePlatformsUseCase
+	GetRegionVaccinePlatformsUC  usecases.GetRegionVaccinePlatformsUseCase
+	Logger                       logger.Logger
+}
+
+func NewPlatformHandler(getCountryVaccinePlatformsUC usecases.GetCountryVaccinePlatformsUseCase, getRegionVaccinePlatformsUC usecases.GetRegionVaccinePlatformsUseCase, logger logger.Logger) *PlatformHandler {
+	return &PlatformHandler{
+		GetCountryVaccinePlatformsUC: getCountryVaccinePlatformsUC,
+		GetRegionVaccinePlatformsUC:  getRegionVaccinePlatformsUC,
+		Logger:                       logger,
+	}
+}
+
+// GetCountryPlatforms godoc
+// @Summary Retrieve the vaccine platform mix of a country
+// @Description Groups the vaccines used in a country by platform (mRNA, viral vector, inactivated, protein subunit, ...), with the share of its vaccines each one accounts for. Vaccines without a known platform are grouped as unknown
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
+// @Success 200 {object} usecases.GetCountryVaccinePlatformsOutput "Successful retrieval of the country's platform mix"
+// @Failure 400 {object} Problem "Unknown country code"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/countries/{countryCode}/vaccines/platforms [get]
+func (h *PlatformHandler) GetCountryPlatforms(c *gin.Context) {
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
+
+	input := usecases.GetCountryVaccinePlatformsInput{
+		CountryCode: countryCode,
+	}
+
+	output, err := h.GetCountryVaccinePlatformsUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetCountryVaccinePlatformsUseCase", err, "Failed to retrieve country vaccine platforms.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetRegionPlatforms godoc
+// @Summary Retrieve the vaccine platform mix of a region
+// @Description Groups the vaccines used in a WHO region by platform, with the share of the region's countries that use at least one vaccine of each, most adopted first
+// @Tags Platform
+// @Accept json
+// @Produce json
+// @Param regionName path string true "WHO region (e.g., AMRO, EURO, SEARO)"
+// @Success 200 {object} usecases.GetRegionVaccinePlatformsOutput "Successful retrieval of the region's platform mix"
+// @Failure 404 {object} Problem "Unknown region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions/{regionName}/vaccines/platforms [get]
+func (h *PlatformHandler) GetRegionPlatforms(c *gin.Context) {
+	input := usecases.GetRegionVaccinePlatformsInput{
+		RegionName: c.Param("regionName"),
+	}
+
+	output, err := h.GetRegionVaccinePlatformsUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionVaccinePlatformsUseCase", err, "Failed to retrieve region vaccine platforms.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body of every error response. Code identifies the
+// problem and does not change between releases, so clients can branch on it;
+// Title and Detail are meant for people and may change.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+// Codes of the problems the API answers with
+const (
+	codeNotFound            = "not_found"
+	codeInvalidInput        = "invalid_input"
+	codeInvalidCountryCode  = "invalid_country_code"
+	codeInvalidDate         = "invalid_date"
+	codeInvalidMode         = "invalid_mode"
+	codeInvalidMetric       = "invalid_metric"
+	codeInvalidOrder        = "invalid_order"
+	codeInvalidLimit        = "invalid_limit"
+	codeInvalidInterval     = "invalid_interval"
+	codeInvalidMatch        = "invalid_match"
+	codeInvalidFilter       = "invalid_filter"
+	codeInvalidSort         = "invalid_sort"
+	codeInvalidCursor       = "invalid_cursor"
+	codeInvalidWeight       = "invalid_weight"
+	codeUpstreamUnavailable = "upstream_unavailable"
+	codeInternalError       = "internal_error"
+)
+
+// writeProblem answers with a problem of status, identified by code, and
+// stops the remaining handlers.
+func writeProblem(c *gin.Context, status int, code, detail string) {
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(status, Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.RequestURI(),
+		Code:     code,
+	})
+}
+
+// writeUseCaseError answers with the problem matching an error returned by
+// useCase. Errors of none of the domain kinds are logged and answered with
+// failure, since their message may expose internals.
+func writeUseCaseError(c *gin.Context, log logger.Logger, useCase string, err error, failure string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeProblem(c, http.StatusNotFound, codeNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidInput):
+		writeProblem(c, http.StatusBadRequest, codeInvalidInput, err.Error())
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		log.Errorf("Error executing %s: %v", useCase, err)
+		writeProblem(c, http.StatusServiceUnavailable, codeUpstreamUnavailable, "The database is unavailable. Try again later.")
+	default:
+		log.Errorf("Error executing %s: %v", useCase, err)
+		writeProblem(c, http.StatusInternalServerError, codeInternalError, failure)
+	}
+}
+
+// RouteNotFound answers requests no route matches.
+func RouteNotFound(c *gin.Context) {
+	writeProblem(c, http.StatusNotFound, codeNotFound, "No route matches the request.")
+}
+
+// Recovered answers requests whose handler panicked, once gin has logged the
+// panic.
+func Recovered(c *gin.Context, _ interface{}) {
+	writeProblem(c, http.StatusInternalServerError, codeInternalError, "Unexpected error.")
+}
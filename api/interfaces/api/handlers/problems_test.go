@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/domain"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+func TestWriteUseCaseError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "Not found",
+			err:        fmt.Errorf("loading: %w", domain.NotFoundf("country with code XYZ not found")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+			wantDetail: "loading: country with code XYZ not found",
+		},
+		{
+			name:       "Invalid input",
+			err:        domain.InvalidInputf("from 2021-02-01 is after to 2021-01-01"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidInput,
+			wantDetail: "from 2021-02-01 is after to 2021-01-01",
+		},
+		{
+			name:       "Upstream unavailable",
+			err:        domain.Unavailable("Neo4j", errors.New("connection refused")),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   codeUpstreamUnavailable,
+			wantDetail: "The database is unavailable. Try again later.",
+		},
+		{
+			name:       "Unexpected",
+			err:        errors.New("record has no name column"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codeInternalError,
+			wantDetail: "Failed to retrieve data.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/countries/XYZ/profile?as_of=2021-01-01", nil)
+
+			writeUseCaseError(c, logger.NewLogrusLogger(), "TestUseCase", tt.err, "Failed to retrieve data.")
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d; want %d", recorder.Code, tt.wantStatus)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != problemContentType {
+				t.Errorf("Content-Type = %q; want %q", contentType, problemContentType)
+			}
+
+			var problem Problem
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not a problem: %v", err)
+			}
+			want := Problem{
+				Type:     "about:blank",
+				Title:    http.StatusText(tt.wantStatus),
+				Status:   tt.wantStatus,
+				Detail:   tt.wantDetail,
+				Instance: "/api/v1/countries/XYZ/profile?as_of=2021-01-01",
+				Code:     tt.wantCode,
+			}
+			if problem != want {
+				t.Errorf("problem = %+v; want %+v", problem, want)
+			}
+		})
+	}
+}
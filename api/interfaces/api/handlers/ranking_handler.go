@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+const (
+	defaultRankingLimit = 10
+	maxRankingLimit     = 250
+)
+
+type RankingHandler struct {
+	GetRankingUC usecases.GetRankingUseCase
+	Logger       logger.Logger
+}
+
+func NewRankingHandler(getRankingUC usecases.GetRankingUseCase, logger logger.Logger) *RankingHandler {
+	return &RankingHandler{
+		GetRankingUC: getRankingUC,
+		Logger:       logger,
+	}
+}
+
+// GetRanking godoc
+// @Summary Rank countries by a metric
+// @Description Returns the top countries by a COVID-19 or vaccination metric at a date, with ranks, ties and country metadata
+// @Tags Rankings
+// @Accept json
+// @Produce json
+// @Param metric query string true "Metric to rank by" Enums(cumulative_cases, cumulative_deaths, new_cases, new_deaths, total_vaccinations, total_vaccinations_per100, persons_vaccinated, persons_vaccinated_per100, persons_fully_vaccinated, persons_fully_vaccinated_per100, persons_booster, persons_booster_per100)
+// @Param date query string false "Date in YYYY-MM-DD format, today by default" format(date)
+// @Param mode query string false "as_of (default) uses each country's latest report on or before the date, exact only reports on the date" Enums(as_of, exact)
+// @Param limit query int false "Number of countries, 10 by default and at most 250"
+// @Param order query string false "desc (default) or asc" Enums(desc, asc)
+// @Param region query string false "Only rank countries of this WHO region"
+// @Success 200 {object} usecases.GetRankingOutput "Successful retrieval of the ranking"
+// @Failure 400 {object} Problem "Invalid metric, date, mode, limit or order"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/rankings [get]
+func (h *RankingHandler) GetRanking(c *gin.Context) {
+	metric, err := valueobjects.ParseRankingMetric(c.Query("metric"))
+	if err != nil {
+		h.Logger.Warnf("Invalid ranking metric: %v", err)
+		metrics := make([]string, len(valueobjects.RankingMetrics))
+		for i, known := range valueobjects.RankingMetrics {
+			metrics[i] = string(known)
+		}
+		writeProblem(c, http.StatusBadRequest, codeInvalidMetric, fmt.Sprintf("Invalid metric. Use one of %s.", strings.Join(metrics, ", ")))
+		return
+	}
+
+	date, ok := optionalDateQuery(c, "date")
+	if !ok {
+		return
+	}
+	if date.IsZero() {
+		date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	mode, ok := dateModeParam(c)
+	if !ok {
+		return
+	}
+
+	order, err := valueobjects.ParseSortOrder(c.Query("order"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidOrder, "Invalid order. Use desc or asc.")
+		return
+	}
+
+	limit := defaultRankingLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxRankingLimit {
+			writeProblem(c, http.StatusBadRequest, codeInvalidLimit, "Invalid limit. Use a number from 1 to 250.")
+			return
+		}
+	}
+
+	input := usecases.GetRankingInput{
+		Metric: metric,
+		Date:   date,
+		Mode:   mode,
+		Order:  order,
+		Region: c.Query("region"),
+		Limit:  limit,
+	}
+
+	output, err := h.GetRankingUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRankingUseCase", err, "Failed to retrieve ranking.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
+)
+
+type RegionHandler struct {
+	GetRegionsUC         usecases.GetRegionsUseCase
+	GetRegionCountriesUC usecases.GetRegionCountriesUseCase
+	GetRegionCovidUC     usecases.GetRegionCovidUseCase
+	Logger               logger.Logger
+}
+
+func NewRegionHandler(getRegionsUC usecases.GetRegionsUseCase, getRegionCountriesUC usecases.GetRegionCountriesUseCase, getRegionCovidUC usecases.GetRegionCovidUseCase, logger logger.Logger) *RegionHandler {
+	return &RegionHandler{
+		GetRegionsUC:         getRegionsUC,
+		GetRegionCountriesUC: getRegionCountriesUC,
+		GetRegionCovidUC:     getRegionCovidUC,
+		Logger:               logger,
+	}
+}
+
+// GetRegions godoc
+// @Summary List regions
+// @Description Lists the WHO regions with the number of countries in each
+// @Tags Region
+// @Accept json
+// @Produce json
+// @Success 200 {object} usecases.GetRegionsOutput "Successful retrieval of regions"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions [get]
+func (h *RegionHandler) GetRegions(c *gin.Context) {
+	output, err := h.GetRegionsUC.Execute(c.Request.Context())
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionsUseCase", err, "Failed to retrieve regions.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetRegionCountries godoc
+// @Summary List the countries of a region
+// @Description Lists the countries that belong to a WHO region
+// @Tags Region
+// @Accept json
+// @Produce json
+// @Param regionName path string true "WHO region (e.g., AMRO, EURO, SEARO)"
+// @Success 200 {object} usecases.GetRegionCountriesOutput "Successful retrieval of the region's countries"
+// @Failure 404 {object} Problem "Unknown region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions/{regionName}/countries [get]
+func (h *RegionHandler) GetRegionCountries(c *gin.Context) {
+	input := usecases.GetRegionCountriesInput{
+		RegionName: c.Param("regionName"),
+	}
+
+	output, err := h.GetRegionCountriesUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionCountriesUseCase", err, "Failed to retrieve region countries.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetRegionCovid godoc
+// @Summary Retrieve COVID-19 totals for a region
+// @Description Sums the COVID-19 cases and deaths of the countries of a WHO region at a date, with each country's contribution and the share of countries that reported
+// @Tags Region
+// @Accept json
+// @Produce json
+// @Param regionName path string true "WHO region (e.g., AMRO, EURO, SEARO)"
+// @Param date query string false "Date in YYYY-MM-DD format, today by default" format(date)
+// @Param mode query string false "as_of (default) uses each country's latest report on or before the date, exact only reports on the date" Enums(as_of, exact)
+// @Success 200 {object} usecases.GetRegionCovidOutput "Successful retrieval of the region's COVID-19 totals"
+// @Failure 400 {object} Problem "Invalid date format or mode"
+// @Failure 404 {object} Problem "Unknown region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions/{regionName}/covid [get]
+func (h *RegionHandler) GetRegionCovid(c *gin.Context) {
+	date, ok := optionalDateQuery(c, "date")
+	if !ok {
+		return
+	}
+	if date.IsZero() {
+		date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	mode, ok := dateModeParam(c)
+	if !ok {
+		return
+	}
+
+	input := usecases.GetRegionCovidInput{
+		RegionName: c.Param("regionName"),
+		Date:       date,
+		Mode:       mode,
+	}
+
+	output, err := h.GetRegionCovidUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionCovidUseCase", err, "Failed to retrieve region COVID totals.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
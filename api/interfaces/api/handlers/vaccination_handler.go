@@ -27,32 +27,43 @@ func NewVaccinationHandler(getVaccinatedPeopleUC usecases.GetVaccinatedPeopleUse
 // @Tags Vaccination
 // @Accept json
 // @Produce json
-// @Param countryCode path string true "ISO Country Code"
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
 // @Param date path string true "Date in YYYY-MM-DD format" format(date)
+// @Param mode query string false "as_of (default) returns the latest report on or before the date, exact only a report on the date" Enums(as_of, exact)
 // @Success 200 {object} usecases.GetVaccinatedPeopleOutput "Successful retrieval of vaccinated people data"
-// @Failure 400 {object} map[string]string "Invalid date format"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} Problem "Invalid date format, mode or country code"
+// @Failure 404 {object} Problem "No vaccination statistics for the country and date"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
 // @Router /api/v1/countries/{countryCode}/vaccinations/{date} [get]
 func (h *VaccinationHandler) GetVaccinatedPeople(c *gin.Context) {
-	countryCode := c.Param("countryCode")
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
 	dateStr := c.Param("date")
 
 	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
 		h.Logger.Warnf("Invalid date format: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD."})
+		writeProblem(c, http.StatusBadRequest, codeInvalidDate, "Invalid date format. Use YYYY-MM-DD.")
+		return
+	}
+
+	mode, ok := dateModeParam(c)
+	if !ok {
 		return
 	}
 
 	input := usecases.GetVaccinatedPeopleInput{
 		CountryCode: countryCode,
 		Date:        date,
+		Mode:        mode,
 	}
 
 	output, err := h.GetVaccinatedPeopleUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		h.Logger.Errorf("Error executing GetVaccinatedPeopleUseCase: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vaccinated people data."})
+		writeUseCaseError(c, h.Logger, "GetVaccinatedPeopleUseCase", err, "Failed to retrieve vaccinated people data.")
 		return
 	}
 
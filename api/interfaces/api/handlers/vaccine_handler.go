@@ -2,38 +2,136 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/thalesmacedo1/covid-api/application/usecases"
+	"github.com/thalesmacedo1/covid-api/domain/valueobjects"
 	"github.com/thalesmacedo1/covid-api/infrastructure/logger"
 )
 
 type VaccineHandler struct {
-	GetVaccinesUsedUC    usecases.GetVaccinesUsedUseCase
-	GetMostUsedVaccineUC usecases.GetMostUsedVaccineUseCase
-	Logger               logger.Logger
+	GetVaccinesUC             usecases.GetVaccinesUseCase
+	GetVaccineCountriesUC     usecases.GetVaccineCountriesUseCase
+	GetVaccineAdoptionUC      usecases.GetVaccineAdoptionUseCase
+	GetVaccinesUsedUC         usecases.GetVaccinesUsedUseCase
+	GetMostUsedVaccineUC      usecases.GetMostUsedVaccineUseCase
+	GetRegionVaccineRankingUC usecases.GetRegionVaccineRankingUseCase
+	Logger                    logger.Logger
 }
 
-func NewVaccineHandler(getVaccinesUsedUC usecases.GetVaccinesUsedUseCase, getMostUsedVaccineUC usecases.GetMostUsedVaccineUseCase, logger logger.Logger) *VaccineHandler {
+func NewVaccineHandler(getVaccinesUC usecases.GetVaccinesUseCase, getVaccineCountriesUC usecases.GetVaccineCountriesUseCase, getVaccineAdoptionUC usecases.GetVaccineAdoptionUseCase, getVaccinesUsedUC usecases.GetVaccinesUsedUseCase, getMostUsedVaccineUC usecases.GetMostUsedVaccineUseCase, getRegionVaccineRankingUC usecases.GetRegionVaccineRankingUseCase, logger logger.Logger) *VaccineHandler {
 	return &VaccineHandler{
-		GetVaccinesUsedUC:    getVaccinesUsedUC,
-		GetMostUsedVaccineUC: getMostUsedVaccineUC,
-		Logger:               logger,
+		GetVaccinesUC:             getVaccinesUC,
+		GetVaccineCountriesUC:     getVaccineCountriesUC,
+		GetVaccineAdoptionUC:      getVaccineAdoptionUC,
+		GetVaccinesUsedUC:         getVaccinesUsedUC,
+		GetMostUsedVaccineUC:      getMostUsedVaccineUC,
+		GetRegionVaccineRankingUC: getRegionVaccineRankingUC,
+		Logger:                    logger,
 	}
 }
 
+// GetVaccines godoc
+// @Summary List vaccines
+// @Description Lists every vaccine with its company, vaccine name and the number of countries that use it
+// @Tags Vaccine
+// @Accept json
+// @Produce json
+// @Success 200 {object} usecases.GetVaccinesOutput "Successful retrieval of vaccines"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/vaccines [get]
+func (h *VaccineHandler) GetVaccines(c *gin.Context) {
+	output, err := h.GetVaccinesUC.Execute(c.Request.Context())
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetVaccinesUseCase", err, "Failed to retrieve vaccines.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetVaccineCountries godoc
+// @Summary List the countries that use a vaccine
+// @Description Lists the countries that use a vaccine, with the start, authorization and end dates each country reported. Products containing a slash must be percent-encoded (%2F)
+// @Tags Vaccine
+// @Accept json
+// @Produce json
+// @Param product path string true "Product as listed by /api/v1/vaccines (e.g., Pfizer BioNTech - Comirnaty)"
+// @Param region query string false "Only list countries of this WHO region"
+// @Success 200 {object} usecases.GetVaccineCountriesOutput "Successful retrieval of the vaccine's countries"
+// @Failure 404 {object} Problem "Unknown vaccine or region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/vaccines/{product}/countries [get]
+func (h *VaccineHandler) GetVaccineCountries(c *gin.Context) {
+	input := usecases.GetVaccineCountriesInput{
+		Product:    c.Param("product"),
+		RegionName: c.Query("region"),
+	}
+
+	output, err := h.GetVaccineCountriesUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetVaccineCountriesUseCase", err, "Failed to retrieve vaccine countries.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetVaccineAdoption godoc
+// @Summary Retrieve how a vaccine spread across countries
+// @Description Returns the cumulative number of countries that started using a vaccine over time, its first adopter, the median days from authorization to first use and the same figures by WHO region. Products containing a slash must be percent-encoded (%2F)
+// @Tags Vaccine
+// @Accept json
+// @Produce json
+// @Param product path string true "Product as listed by /api/v1/vaccines (e.g., Pfizer BioNTech - Comirnaty)"
+// @Param interval query string false "Period the timeline is grouped by, day by default" Enums(day, week, month)
+// @Success 200 {object} usecases.GetVaccineAdoptionOutput "Successful retrieval of the vaccine's adoption"
+// @Failure 400 {object} Problem "Invalid interval"
+// @Failure 404 {object} Problem "Unknown vaccine"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/vaccines/{product}/adoption [get]
+func (h *VaccineHandler) GetVaccineAdoption(c *gin.Context) {
+	interval, err := valueobjects.ParseInterval(c.Query("interval"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidInterval, "Invalid interval. Use day, week or month.")
+		return
+	}
+
+	input := usecases.GetVaccineAdoptionInput{
+		Product:  c.Param("product"),
+		Interval: interval,
+	}
+
+	output, err := h.GetVaccineAdoptionUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetVaccineAdoptionUseCase", err, "Failed to retrieve vaccine adoption.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
 // GetVaccinesUsed godoc
 // @Summary Retrieve vaccines used in a country
-// @Description Fetches the list of vaccines used in a specific country
+// @Description Fetches the list of vaccines used in a specific country, with the start, authorization and end dates that country reported
 // @Tags Vaccine
 // @Accept json
 // @Produce json
-// @Param countryCode path string true "ISO Country Code"
+// @Param countryCode path string true "ISO 3166 alpha-2, alpha-3 or numeric country code"
 // @Success 200 {object} usecases.GetVaccinesUsedOutput "Successful retrieval of vaccines used"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} Problem "Unknown country code"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
 // @Router /api/v1/countries/{countryCode}/vaccines [get]
 func (h *VaccineHandler) GetVaccinesUsed(c *gin.Context) {
-	countryCode := c.Param("countryCode")
+	countryCode, ok := countryCodeParam(c)
+	if !ok {
+		return
+	}
 
 	input := usecases.GetVaccinesUsedInput{
 		CountryCode: countryCode,
@@ -41,8 +139,7 @@ func (h *VaccineHandler) GetVaccinesUsed(c *gin.Context) {
 
 	output, err := h.GetVaccinesUsedUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		h.Logger.Errorf("Error executing GetVaccinesUsedUseCase: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve vaccines used."})
+		writeUseCaseError(c, h.Logger, "GetVaccinesUsedUseCase", err, "Failed to retrieve vaccines used.")
 		return
 	}
 
@@ -51,13 +148,15 @@ func (h *VaccineHandler) GetVaccinesUsed(c *gin.Context) {
 
 // GetMostUsedVaccine godoc
 // @Summary Retrieve the most used vaccine in a region
-// @Description Finds the most commonly used vaccine in a specified region
+// @Description Finds the vaccine adopted by the most countries of a region. Ties are broken by product name
 // @Tags Vaccine
 // @Accept json
 // @Produce json
-// @Param regionName path string true "Region Name (e.g., South America, Europe, Asia)"
+// @Param regionName path string true "WHO region code (e.g., AMRO, EURO, WPRO)"
 // @Success 200 {object} usecases.GetMostUsedVaccineOutput "Successful retrieval of most used vaccine"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 404 {object} Problem "No vaccines reported in the region"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
 // @Router /api/v1/regions/{regionName}/vaccines/most-used [get]
 func (h *VaccineHandler) GetMostUsedVaccine(c *gin.Context) {
 	regionName := c.Param("regionName")
@@ -68,8 +167,53 @@ func (h *VaccineHandler) GetMostUsedVaccine(c *gin.Context) {
 
 	output, err := h.GetMostUsedVaccineUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		h.Logger.Errorf("Error executing GetMostUsedVaccineUseCase: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve most used vaccine."})
+		writeUseCaseError(c, h.Logger, "GetMostUsedVaccineUseCase", err, "Failed to retrieve most used vaccine.")
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// GetRegionVaccineRanking godoc
+// @Summary Rank the vaccines used in a region
+// @Description Ranks the vaccines of a region by the countries that adopted them or by an estimate of the doses administered, assuming each country splits its doses evenly between its vaccines, with ranks, ties and each vaccine's share of the region
+// @Tags Vaccine
+// @Accept json
+// @Produce json
+// @Param regionName path string true "WHO region code (e.g., AMRO, EURO, WPRO)"
+// @Param weight query string false "What usage is measured by, countries by default" Enums(countries, estimated_doses)
+// @Param limit query int false "Number of vaccines, 10 by default and at most 250"
+// @Success 200 {object} usecases.GetRegionVaccineRankingOutput "Successful retrieval of the vaccine ranking"
+// @Failure 400 {object} Problem "Invalid weight or limit"
+// @Failure 404 {object} Problem "Region not found"
+// @Failure 500 {object} Problem "Internal server error"
+// @Failure 503 {object} Problem "Database unavailable"
+// @Router /api/v1/regions/{regionName}/vaccines/ranking [get]
+func (h *VaccineHandler) GetRegionVaccineRanking(c *gin.Context) {
+	weight, err := valueobjects.ParseVaccineWeight(c.Query("weight"))
+	if err != nil {
+		writeProblem(c, http.StatusBadRequest, codeInvalidWeight, "Invalid weight. Use countries or estimated_doses.")
+		return
+	}
+
+	limit := defaultRankingLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxRankingLimit {
+			writeProblem(c, http.StatusBadRequest, codeInvalidLimit, "Invalid limit. Use a number from 1 to 250.")
+			return
+		}
+	}
+
+	input := usecases.GetRegionVaccineRankingInput{
+		RegionName: c.Param("regionName"),
+		Weight:     weight,
+		Limit:      limit,
+	}
+
+	output, err := h.GetRegionVaccineRankingUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		writeUseCaseError(c, h.Logger, "GetRegionVaccineRankingUseCase", err, "Failed to retrieve vaccine ranking.")
 		return
 	}
 
@@ -10,23 +10,53 @@ import (
 	"github.com/thalesmacedo1/covid-api/interfaces/middleware"
 )
 
-func Router(covidHandler *handlers.CovidHandler, vaccinationHandler *handlers.VaccinationHandler, vaccineHandler *handlers.VaccineHandler, logger logger.Logger) *gin.Engine {
+func Router(countryHandler *handlers.CountryHandler, covidHandler *handlers.CovidHandler, vaccinationHandler *handlers.VaccinationHandler, vaccineHandler *handlers.VaccineHandler, rankingHandler *handlers.RankingHandler, regionHandler *handlers.RegionHandler, companyHandler *handlers.CompanyHandler, platformHandler *handlers.PlatformHandler, logger logger.Logger) *gin.Engine {
 	router := gin.New()
+	// Produtos de vacinas podem conter "/", enviado como %2F e decodificado só depois de escolher a rota
+	router.UseRawPath = true
 
 	// Middleware
-	router.Use(gin.Recovery())
+	router.Use(gin.CustomRecovery(handlers.Recovered))
 	router.Use(middleware.LoggerMiddleware(logger))
 
+	// Erros fora das rotas também seguem o formato application/problem+json
+	router.NoRoute(handlers.RouteNotFound)
+
 	// use ginSwagger middleware to serve the API docs
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
+	// Country Endpoints
+
+	// Catálogo de países com busca, filtros e paginação
+	router.GET("/api/v1/countries", countryHandler.GetCountries)
+
+	// Perfil completo de um país: identificação, região, casos, vacinação e vacinas
+	router.GET("/api/v1/countries/:countryCode/profile", countryHandler.GetProfile)
+
 	// COVID Endpoints
 
 	// 1. Qual foi o total acumulado de casos e mortes de Covid-19 em um país específico em uma data determinada?
 	router.GET("/api/v1/countries/:countryCode/covid/:date", covidHandler.GetTotals)
 
+	// Série temporal de casos e mortes de um país, por dia, semana ou mês
+	router.GET("/api/v1/countries/:countryCode/covid", covidHandler.GetSeries)
+
 	// 4. Qual país registrou o maior número de casos acumulados até uma data específica?
-	router.GET("/api/v1/countries/highest-cases?:date", covidHandler.GetCountryWithMostCases)
+	router.GET("/api/v1/countries/highest-cases", covidHandler.GetCountryWithMostCases)
+
+	// Ranking Endpoints
+
+	// Ranking dos países por qualquer métrica de casos, mortes ou vacinação em uma data
+	router.GET("/api/v1/rankings", rankingHandler.GetRanking)
+
+	// Region Endpoints
+
+	// Regiões da OMS e seus países
+	router.GET("/api/v1/regions", regionHandler.GetRegions)
+	router.GET("/api/v1/regions/:regionName/countries", regionHandler.GetRegionCountries)
+
+	// Total de casos e mortes de uma região em uma data, com a contribuição de cada país
+	router.GET("/api/v1/regions/:regionName/covid", regionHandler.GetRegionCovid)
 
 	// Vaccination Endpoints
 
@@ -35,11 +65,39 @@ func Router(covidHandler *handlers.CovidHandler, vaccinationHandler *handlers.Va
 
 	// Vaccine Endpoints
 
+	// Catálogo de vacinas com o número de países que usam cada uma
+	router.GET("/api/v1/vaccines", vaccineHandler.GetVaccines)
+
+	// Países que usam uma vacina, com as datas informadas por cada um
+	router.GET("/api/v1/vaccines/:product/countries", vaccineHandler.GetVaccineCountries)
+
+	// Difusão de uma vacina entre os países ao longo do tempo e por região
+	router.GET("/api/v1/vaccines/:product/adoption", vaccineHandler.GetVaccineAdoption)
+
 	// 3. Quais foram as vacinas usadas em um determinado país e em que data elas começaram a ser aplicadas?
 	router.GET("/api/v1/countries/:countryCode/vaccines", vaccineHandler.GetVaccinesUsed)
 
 	// 5. Qual foi a vacina mais utilizada em uma região específica?
 	router.GET("/api/v1/regions/:regionName/vaccines/most-used", vaccineHandler.GetMostUsedVaccine)
 
+	// Vacinas de uma região ordenadas pelos países que as adotaram ou por uma estimativa das doses aplicadas
+	router.GET("/api/v1/regions/:regionName/vaccines/ranking", vaccineHandler.GetRegionVaccineRanking)
+
+	// Company Endpoints
+
+	// Fabricantes com o número de vacinas e a fração dos países que usam ao menos uma delas
+	router.GET("/api/v1/companies", companyHandler.GetCompanies)
+
+	// Participação de cada fabricante entre os países de uma região
+	router.GET("/api/v1/regions/:regionName/companies", companyHandler.GetRegionCompanies)
+
+	// Platform Endpoints
+
+	// Plataformas (mRNA, vetor viral, inativada, subunidade proteica...) das vacinas usadas em um país
+	router.GET("/api/v1/countries/:countryCode/vaccines/platforms", platformHandler.GetCountryPlatforms)
+
+	// Fração dos países de uma região que usam vacinas de cada plataforma
+	router.GET("/api/v1/regions/:regionName/vaccines/platforms", platformHandler.GetRegionPlatforms)
+
 	return router
 }
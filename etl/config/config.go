@@ -3,14 +3,43 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+
+	"github.com/thalesmacedo1/covid-etl/utils"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	DefaultBatchSize  = 1000
+	defaultRejectFile = "rejects.csv"
+	// The share applies to each whole file; the WHO file has 2.5% of its rows
+	// for international conveyances, all of them rejected
+	DefaultMaxRejectRatio = 0.05
+)
+
 type Config struct {
 	Neo4jURI      string
 	Neo4jUser     string
 	Neo4jPassword string
+
+	// Number of CSV rows written per UNWIND transaction
+	BatchSize int
+
+	// Where rejected and coerced rows are written, and in which format
+	RejectFile   string
+	RejectFormat utils.RejectFormat
+	// Share of a file's rows that may be rejected before the run fails
+	MaxRejectRatio float64
+
+	// Whether countries outside the ISO 3166 table are deleted with their stats
+	DeleteNonCanonicalCountries bool
+
+	// Redis server the import completed event is published on, none when empty
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
 }
 
 var Settings Config
@@ -21,14 +50,64 @@ func LoadConfig(envFile string) error {
 	}
 
 	Settings = Config{
-		Neo4jURI:      os.Getenv("NEO4J_URI"),
-		Neo4jUser:     os.Getenv("NEO4J_USER"),
-		Neo4jPassword: os.Getenv("NEO4J_PASSWORD"),
+		Neo4jURI:       os.Getenv("NEO4J_URI"),
+		Neo4jUser:      os.Getenv("NEO4J_USER"),
+		Neo4jPassword:  os.Getenv("NEO4J_PASSWORD"),
+		BatchSize:      DefaultBatchSize,
+		RejectFile:     defaultRejectFile,
+		RejectFormat:   utils.RejectFormatCSV,
+		MaxRejectRatio: DefaultMaxRejectRatio,
+		RedisAddr:      strings.TrimSpace(os.Getenv("REDIS_HOST")),
+		RedisPassword:  strings.TrimSpace(os.Getenv("REDIS_PASSWORD")),
 	}
 
 	if Settings.Neo4jURI == "" || Settings.Neo4jUser == "" || Settings.Neo4jPassword == "" {
 		log.Fatal("Missing required environment variables")
 	}
 
+	if batchSizeStr := strings.TrimSpace(os.Getenv("ETL_BATCH_SIZE")); batchSizeStr != "" {
+		batchSize, err := strconv.Atoi(batchSizeStr)
+		if err != nil || batchSize <= 0 {
+			log.Fatalf("Invalid ETL_BATCH_SIZE value: %q", batchSizeStr)
+		}
+		Settings.BatchSize = batchSize
+	}
+
+	if rejectFile := strings.TrimSpace(os.Getenv("ETL_REJECT_FILE")); rejectFile != "" {
+		Settings.RejectFile = rejectFile
+	}
+
+	if formatStr := strings.TrimSpace(os.Getenv("ETL_REJECT_FORMAT")); formatStr != "" {
+		format, err := utils.ParseRejectFormat(formatStr)
+		if err != nil {
+			log.Fatalf("Invalid ETL_REJECT_FORMAT value: %v", err)
+		}
+		Settings.RejectFormat = format
+	}
+
+	if ratioStr := strings.TrimSpace(os.Getenv("ETL_MAX_REJECT_RATIO")); ratioStr != "" {
+		ratio, err := strconv.ParseFloat(ratioStr, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			log.Fatalf("Invalid ETL_MAX_REJECT_RATIO value: %q", ratioStr)
+		}
+		Settings.MaxRejectRatio = ratio
+	}
+
+	if deleteStr := strings.TrimSpace(os.Getenv("ETL_DELETE_NON_CANONICAL_COUNTRIES")); deleteStr != "" {
+		deleteCountries, err := strconv.ParseBool(deleteStr)
+		if err != nil {
+			log.Fatalf("Invalid ETL_DELETE_NON_CANONICAL_COUNTRIES value: %q", deleteStr)
+		}
+		Settings.DeleteNonCanonicalCountries = deleteCountries
+	}
+
+	if dbStr := strings.TrimSpace(os.Getenv("REDIS_DB")); dbStr != "" {
+		db, err := strconv.Atoi(dbStr)
+		if err != nil || db < 0 {
+			log.Fatalf("Invalid REDIS_DB value: %q", dbStr)
+		}
+		Settings.RedisDB = db
+	}
+
 	return nil
 }
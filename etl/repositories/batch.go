@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// BatchStats summarises what a single UNWIND write committed.
+//
+// Inserted, Updated and Unchanged are only filled in by upserting writes,
+// which report them through the inserted, updated and unchanged columns of
+// their single result record. Rows the write skipped, such as those whose
+// country does not exist, count in none of them.
+type BatchStats struct {
+	Rows                 int
+	NodesCreated         int
+	RelationshipsCreated int
+	PropertiesSet        int
+	Inserted             int
+	Updated              int
+	Unchanged            int
+	Duration             time.Duration
+}
+
+func (s *BatchStats) Add(other BatchStats) {
+	s.Rows += other.Rows
+	s.NodesCreated += other.NodesCreated
+	s.RelationshipsCreated += other.RelationshipsCreated
+	s.PropertiesSet += other.PropertiesSet
+	s.Inserted += other.Inserted
+	s.Updated += other.Updated
+	s.Unchanged += other.Unchanged
+	s.Duration += other.Duration
+}
+
+func (s BatchStats) String() string {
+	msg := fmt.Sprintf("%d rows, %d nodes created, %d relationships created, %d properties set",
+		s.Rows, s.NodesCreated, s.RelationshipsCreated, s.PropertiesSet)
+	if s.Inserted+s.Updated+s.Unchanged > 0 {
+		msg += fmt.Sprintf(" (%d inserted, %d updated, %d unchanged)", s.Inserted, s.Updated, s.Unchanged)
+	}
+	return fmt.Sprintf("%s in %v", msg, s.Duration)
+}
+
+// ChangedRow identifies a row an upserting write inserted or updated, by the
+// alpha-3 code of its country and, for dated rows, its date.
+type ChangedRow struct {
+	CountryCode string
+	Date        *time.Time
+}
+
+type batchResult struct {
+	record  *neo4j.Record
+	summary neo4j.ResultSummary
+}
+
+// writeBatch runs query once with the given rows bound to $rows, inside a
+// single managed write transaction. Upserting writes return the rows they
+// inserted or updated in a changed column of maps with a countryCode and an
+// optional date.
+func writeBatch(ctx context.Context, driver neo4j.DriverWithContext, query string, rows []map[string]interface{}) (BatchStats, []ChangedRow, error) {
+	if len(rows) == 0 {
+		return BatchStats{}, nil, nil
+	}
+
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	start := time.Now()
+	res, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, query, map[string]interface{}{"rows": rows})
+		if err != nil {
+			return nil, err
+		}
+
+		var record *neo4j.Record
+		if result.Next(ctx) {
+			record = result.Record()
+		}
+
+		summary, err := result.Consume(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return batchResult{record: record, summary: summary}, nil
+	})
+	if err != nil {
+		return BatchStats{}, nil, err
+	}
+
+	batch := res.(batchResult)
+	counters := batch.summary.Counters()
+	stats := BatchStats{
+		Rows:                 len(rows),
+		NodesCreated:         counters.NodesCreated(),
+		RelationshipsCreated: counters.RelationshipsCreated(),
+		PropertiesSet:        counters.PropertiesSet(),
+		Duration:             time.Since(start),
+	}
+
+	if batch.record != nil {
+		inserted, _, _ := neo4j.GetRecordValue[int64](batch.record, "inserted")
+		updated, _, _ := neo4j.GetRecordValue[int64](batch.record, "updated")
+		unchanged, _, _ := neo4j.GetRecordValue[int64](batch.record, "unchanged")
+		stats.Inserted = int(inserted)
+		stats.Updated = int(updated)
+		stats.Unchanged = int(unchanged)
+	}
+
+	changed, err := changedRows(batch.record)
+	if err != nil {
+		return stats, nil, err
+	}
+	return stats, changed, nil
+}
+
+// changedRows reads the changed column of record, if it has one.
+func changedRows(record *neo4j.Record) ([]ChangedRow, error) {
+	if record == nil {
+		return nil, nil
+	}
+	raw, ok := record.Get("changed")
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	values, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected changed rows %v", raw)
+	}
+
+	changed := make([]ChangedRow, 0, len(values))
+	for _, value := range values {
+		row, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected changed row %v", value)
+		}
+
+		code, _ := row["countryCode"].(string)
+		change := ChangedRow{CountryCode: code}
+		if date, ok := row["date"].(string); ok {
+			parsed, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected changed row date %q: %w", date, err)
+			}
+			change.Date = &parsed
+		}
+		changed = append(changed, change)
+	}
+	return changed, nil
+}
+
+// fingerprint hashes every value of a row so an upsert can tell whether the
+// stored node already holds exactly the same data.
+func fingerprint(row map[string]interface{}) string {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// The type is part of the hash so that a value changing type, such as
+	// a rate stored as 79 becoming 79.0, still counts as a change
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%T:%v;", key, row[key], row[key])
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// nullableInt unwraps n for a query parameter. A nil n leaves the property
+// unset, which keeps "not reported" apart from 0.
+func nullableInt(n *int) interface{} {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
+
+// nullableFloat unwraps f for a query parameter, or returns nil when f is nil.
+func nullableFloat(f *float64) interface{} {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
+
+// nullableDate formats t for date() in a query, or returns nil when t is nil.
+func nullableDate(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
+
+// nullIfEmpty maps blank strings to nil so the property is left unset.
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
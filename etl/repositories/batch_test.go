@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func TestFingerprint(t *testing.T) {
+	row := map[string]interface{}{"countryCode": "BRA", "date": "2021-01-03", "newCases": 10}
+	same := map[string]interface{}{"newCases": 10, "date": "2021-01-03", "countryCode": "BRA"}
+	changed := map[string]interface{}{"countryCode": "BRA", "date": "2021-01-03", "newCases": 11}
+
+	if fingerprint(row) != fingerprint(same) {
+		t.Errorf("fingerprint() differs for rows with the same values")
+	}
+	if fingerprint(row) == fingerprint(changed) {
+		t.Errorf("fingerprint() is equal for rows with different values")
+	}
+}
+
+func TestBatchStatsAdd(t *testing.T) {
+	total := BatchStats{Rows: 2, Inserted: 1, Updated: 1}
+	total.Add(BatchStats{Rows: 3, Inserted: 1, Unchanged: 2, NodesCreated: 4})
+
+	if total.Rows != 5 || total.Inserted != 2 || total.Updated != 1 || total.Unchanged != 2 || total.NodesCreated != 4 {
+		t.Errorf("Add() = %+v", total)
+	}
+}
+
+func TestFingerprintNullableValues(t *testing.T) {
+	zero := 0
+	reported := map[string]interface{}{"newCases": nullableInt(&zero)}
+	missing := map[string]interface{}{"newCases": nullableInt(nil)}
+
+	if missing["newCases"] != nil {
+		t.Errorf("nullableInt(nil) = %v; want nil", missing["newCases"])
+	}
+	if fingerprint(reported) == fingerprint(missing) {
+		t.Errorf("fingerprint() is equal for a missing value and 0")
+	}
+	if fingerprint(reported) != fingerprint(map[string]interface{}{"newCases": nullableInt(&zero)}) {
+		t.Errorf("fingerprint() differs for rows with the same values")
+	}
+
+	rate := 79.0
+	if fingerprint(map[string]interface{}{"rate": 79}) == fingerprint(map[string]interface{}{"rate": nullableFloat(&rate)}) {
+		t.Errorf("fingerprint() is equal for an integer and a decimal rate")
+	}
+}
+
+func TestChangedRows(t *testing.T) {
+	record := &neo4j.Record{
+		Keys: []string{"inserted", "changed"},
+		Values: []interface{}{int64(2), []interface{}{
+			map[string]interface{}{"countryCode": "BRA", "date": "2024-02-10"},
+			map[string]interface{}{"countryCode": "CHL"},
+		}},
+	}
+
+	changed, err := changedRows(record)
+	if err != nil {
+		t.Fatalf("changedRows() error = %v", err)
+	}
+	if len(changed) != 2 || changed[0].CountryCode != "BRA" || changed[0].Date == nil || changed[0].Date.Format("2006-01-02") != "2024-02-10" {
+		t.Fatalf("changedRows() = %+v", changed)
+	}
+	if changed[1].CountryCode != "CHL" || changed[1].Date != nil {
+		t.Errorf("changedRows() undated row = %+v", changed[1])
+	}
+
+	if changed, err := changedRows(&neo4j.Record{Keys: []string{"inserted"}, Values: []interface{}{int64(0)}}); err != nil || changed != nil {
+		t.Errorf("changedRows() without a changed column = %v, %v; want nil, nil", changed, err)
+	}
+
+	bad := &neo4j.Record{Keys: []string{"changed"}, Values: []interface{}{[]interface{}{map[string]interface{}{"countryCode": "BRA", "date": "10/02/2024"}}}}
+	if _, err := changedRows(bad); err == nil {
+		t.Errorf("changedRows() accepted an invalid date")
+	}
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -11,27 +12,111 @@ type CountryRepository struct {
 	driver neo4j.DriverWithContext
 }
 
+// CountryRow describes a country by its canonical ISO 3166 identity. Code is
+// the alpha-3 code, which is also the key of the Country node.
+type CountryRow struct {
+	Name    string
+	Code    string
+	ISO2    string
+	Numeric string
+	Region  string
+}
+
 func NewCountryRepository(driver neo4j.DriverWithContext) *CountryRepository {
 	return &CountryRepository{driver: driver}
 }
 
-func (r *CountryRepository) CreateCountry(ctx context.Context, country, code, region string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer session.Close(ctx)
+func (r *CountryRepository) CreateCountries(ctx context.Context, countries []CountryRow) (BatchStats, error) {
+	rows := make([]map[string]interface{}, 0, len(countries))
+	for _, country := range countries {
+		rows = append(rows, map[string]interface{}{
+			"name":    country.Name,
+			"code":    country.Code,
+			"iso2":    country.ISO2,
+			"numeric": nullIfEmpty(country.Numeric),
+			"region":  country.Region,
+		})
+	}
 
-	_, err := session.Run(ctx,
-		`MERGE (c:Country {code: $code})
-         SET c.name = $name
-         MERGE (r:Region {name: $region})
+	stats, _, err := writeBatch(ctx, r.driver,
+		`UNWIND $rows AS row
+         MERGE (c:Country {code: row.code})
+         SET c.name = row.name, c.iso2 = row.iso2, c.iso3 = row.code, c.numeric = row.numeric
+         MERGE (r:Region {name: row.region})
          MERGE (c)-[:BELONGS]->(r)`,
-		map[string]interface{}{
-			"name":   country,
-			"code":   code,
-			"region": region,
-		},
+		rows,
 	)
 	if err != nil {
-		log.Printf("Error creating country %s: %v", country, err)
+		log.Printf("Error creating batch of %d countries: %v", len(rows), err)
+	}
+	return stats, err
+}
+
+// NonCanonicalCountry is a Country node that was not created from the ISO
+// 3166 table, with the number of stats nodes hanging off it.
+type NonCanonicalCountry struct {
+	Code  string
+	Name  string
+	Stats int
+}
+
+func (c NonCanonicalCountry) String() string {
+	return fmt.Sprintf("%s %q (%d stats)", c.Code, c.Name, c.Stats)
+}
+
+// NonCanonicalCountries lists the Country nodes without an alpha-3 code, such
+// as the nameless ISO-2 duplicates older loads created for the WHO file,
+// ordered by code.
+func (r *CountryRepository) NonCanonicalCountries(ctx context.Context) ([]NonCanonicalCountry, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx,
+		`MATCH (c:Country)
+         WHERE c.iso3 IS NULL
+         RETURN c.code AS code, c.name AS name,
+                COUNT { (c)-[:REPORTED_ON|VACCINATED_ON]->() } AS stats
+         ORDER BY code`,
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var countries []NonCanonicalCountry
+	for result.Next(ctx) {
+		record := result.Record()
+		code, _, _ := neo4j.GetRecordValue[string](record, "code")
+		name, _, _ := neo4j.GetRecordValue[string](record, "name")
+		stats, _, _ := neo4j.GetRecordValue[int64](record, "stats")
+		countries = append(countries, NonCanonicalCountry{Code: code, Name: name, Stats: int(stats)})
+	}
+	return countries, result.Err()
+}
+
+// DeleteNonCanonicalCountries removes the countries NonCanonicalCountries
+// lists together with the stats hanging off them. The loaders recreate the
+// data under the canonical country.
+func (r *CountryRepository) DeleteNonCanonicalCountries(ctx context.Context) (int, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx,
+		`MATCH (c:Country)
+         WHERE c.iso3 IS NULL
+         CALL {
+             WITH c
+             OPTIONAL MATCH (c)-[:REPORTED_ON|VACCINATED_ON]->(s)
+             DETACH DELETE s, c
+         } IN TRANSACTIONS OF 100 ROWS`,
+		nil)
+	if err != nil {
+		log.Printf("Error deleting non-canonical countries: %v", err)
+		return 0, err
+	}
+
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return 0, err
 	}
-	return err
+	return summary.Counters().NodesDeleted(), nil
 }
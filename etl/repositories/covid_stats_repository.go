@@ -12,38 +12,96 @@ type CovidStatsRepository struct {
 	driver neo4j.DriverWithContext
 }
 
+// CovidStatsRow is one WHO report. CountryCode is the canonical alpha-3 code;
+// the other country fields are only used if the country does not exist yet.
+// Nil metrics were not reported and are stored as absent properties.
+type CovidStatsRow struct {
+	CountryCode      string
+	CountryISO2      string
+	CountryNumeric   string
+	CountryName      string
+	Date             time.Time
+	NewCases         *int
+	CumulativeCases  *int
+	NewDeaths        *int
+	CumulativeDeaths *int
+}
+
 func NewCovidStatsRepository(driver neo4j.DriverWithContext) *CovidStatsRepository {
 	return &CovidStatsRepository{driver: driver}
 }
 
-func (r *CovidStatsRepository) CreateCovidStats(ctx context.Context, countryCode string, date time.Time, newCases int, cumulativeCases int, newDeaths int, cumulativeDeaths int) error {
+// UpsertCovidStats writes one CovidStats node per (country, date). Re-running
+// the same file refreshes the values in place instead of duplicating them;
+// a metric that is no longer reported is removed from the node. It returns
+// the rows it inserted or updated.
+func (r *CovidStatsRepository) UpsertCovidStats(ctx context.Context, stats []CovidStatsRow) (BatchStats, []ChangedRow, error) {
+	rows := make([]map[string]interface{}, 0, len(stats))
+	for _, s := range stats {
+		row := map[string]interface{}{
+			"countryCode":      s.CountryCode,
+			"countryISO2":      s.CountryISO2,
+			"countryNumeric":   nullIfEmpty(s.CountryNumeric),
+			"countryName":      s.CountryName,
+			"date":             s.Date.Format("2006-01-02"),
+			"cumulativeCases":  nullableInt(s.CumulativeCases),
+			"newCases":         nullableInt(s.NewCases),
+			"cumulativeDeaths": nullableInt(s.CumulativeDeaths),
+			"newDeaths":        nullableInt(s.NewDeaths),
+		}
+		row["fingerprint"] = fingerprint(row)
+		rows = append(rows, row)
+	}
+
+	batchStats, changed, err := writeBatch(ctx, r.driver,
+		`UNWIND $rows AS row
+         MERGE (c:Country {code: row.countryCode})
+         ON CREATE SET c.name = row.countryName, c.iso2 = row.countryISO2, c.iso3 = row.countryCode, c.numeric = row.countryNumeric
+         MERGE (date:Date {date: date(row.date)})
+         MERGE (s:CovidStats {countryCode: row.countryCode, date: date(row.date)})
+         WITH c, date, s, row,
+              s.fingerprint IS NULL AS inserted,
+              coalesce(s.fingerprint = row.fingerprint, false) AS unchanged
+         FOREACH (_ IN CASE WHEN unchanged THEN [] ELSE [1] END |
+             SET s.cumulativeCases = row.cumulativeCases,
+                 s.newCases = row.newCases,
+                 s.cumulativeDeaths = row.cumulativeDeaths,
+                 s.newDeaths = row.newDeaths,
+                 s.fingerprint = row.fingerprint
+         )
+         MERGE (c)-[:REPORTED_ON]->(s)
+         MERGE (s)-[:ON_DATE]->(date)
+         RETURN sum(CASE WHEN inserted THEN 1 ELSE 0 END) AS inserted,
+                sum(CASE WHEN NOT inserted AND NOT unchanged THEN 1 ELSE 0 END) AS updated,
+                sum(CASE WHEN unchanged THEN 1 ELSE 0 END) AS unchanged,
+                collect(CASE WHEN unchanged THEN null ELSE {countryCode: row.countryCode, date: row.date} END) AS changed`,
+		rows,
+	)
+	if err != nil {
+		log.Printf("Error upserting batch of %d CovidStats: %v", len(rows), err)
+	}
+	return batchStats, changed, err
+}
+
+// DeleteUnkeyedCovidStats removes CovidStats nodes written before stats were
+// keyed by country and date. They can only be duplicates of keyed nodes.
+func (r *CovidStatsRepository) DeleteUnkeyedCovidStats(ctx context.Context) (int, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
-	_, err := session.Run(ctx,
-		`MERGE (c:Country {code: $countryCode})
-             MERGE (date:Date {date: $date})
-             CREATE (s:CovidStats {
-                 cumulativeCases: $cumulativeCases,
-                 newCases: $newCases,
-                 cumulativeDeaths: $cumulativeDeaths,
-                 newDeaths: $newDeaths
-             })
-             MERGE (c)-[:REPORTED_ON]->(s)
-             MERGE (s)-[:ON_DATE]->(date)`,
-		map[string]interface{}{
-			"countryCode":      countryCode,
-			"date":             date,
-			"cumulativeCases":  cumulativeCases,
-			"newCases":         newCases,
-			"cumulativeDeaths": cumulativeDeaths,
-			"newDeaths":        newDeaths,
-		})
-
+	result, err := session.Run(ctx,
+		`MATCH (s:CovidStats)
+         WHERE s.countryCode IS NULL OR s.date IS NULL
+         CALL { WITH s DETACH DELETE s } IN TRANSACTIONS OF 10000 ROWS`,
+		nil)
 	if err != nil {
-		log.Printf("Error creating CovidStats for country %s on date %s: %v", countryCode, date.Format("2006-01-02"), err)
-		return err
+		log.Printf("Error deleting unkeyed CovidStats: %v", err)
+		return 0, err
 	}
 
-	return err
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return summary.Counters().NodesDeleted(), nil
 }
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"log"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type DateRepository struct {
+	driver neo4j.DriverWithContext
+}
+
+func NewDateRepository(driver neo4j.DriverWithContext) *DateRepository {
+	return &DateRepository{driver: driver}
+}
+
+// DeleteSentinelDates removes the 9999-12-31 Date node older loads wrote for
+// blank or malformed dates, and the stats keyed by it. Missing dates are now
+// left unset instead. The oldest loads stored dates as datetimes, so they are
+// compared by their day.
+func (r *DateRepository) DeleteSentinelDates(ctx context.Context) (int, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx,
+		`MATCH (n)
+         WHERE (n:Date OR n:CovidStats OR n:VaccinationStats) AND date(n.date) = date('9999-12-31')
+         CALL { WITH n DETACH DELETE n } IN TRANSACTIONS OF 10000 ROWS`,
+		nil)
+	if err != nil {
+		log.Printf("Error deleting sentinel dates: %v", err)
+		return 0, err
+	}
+
+	summary, err := result.Consume(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return summary.Counters().NodesDeleted(), nil
+}
@@ -12,55 +12,100 @@ type VaccinationStatsRepository struct {
 	driver neo4j.DriverWithContext
 }
 
+// VaccinationStatsRow is one country's vaccination report. Nil metrics were
+// not reported and are stored as absent properties. Per-100 rates keep their
+// decimals.
+type VaccinationStatsRow struct {
+	CountryCode                        string
+	TotalVaccinations                  *int
+	TotalVaccinationsPer100            *float64
+	PersonsVaccinated1PlusDose         *int
+	PersonsVaccinated1PlusDosePer100   *float64
+	PersonsFullyVaccinated             *int
+	PersonsFullyVaccinatedPer100       *float64
+	PersonsBoosterAdditionalDose       *int
+	PersonsBoosterAdditionalDosePer100 *float64
+	DateUpdated                        time.Time
+}
+
 func NewVaccinationStatsRepository(driver neo4j.DriverWithContext) *VaccinationStatsRepository {
 	return &VaccinationStatsRepository{driver: driver}
 }
 
-func (r *VaccinationStatsRepository) CreateVaccinationStats(
-	ctx context.Context,
-	countryCode string,
-	totalVaccinations int,
-	totalVaccinationsPer100 int,
-	personsVaccinated1PlusDose int,
-	personsVaccinated1PlusDosePer100 int,
-	personsFullyVaccinated int,
-	personsFullyVaccinatedPer100 int,
-	personsBoosterAdditionalDose int,
-	personsBoosterAdditionalDosePer100 int,
-	dateUpdated time.Time,
-) error {
+// UpsertVaccinationStats writes one VaccinationStats node per (country, date
+// updated). Re-running the same file refreshes the values in place. It
+// returns the rows it inserted or updated.
+func (r *VaccinationStatsRepository) UpsertVaccinationStats(ctx context.Context, stats []VaccinationStatsRow) (BatchStats, []ChangedRow, error) {
+	rows := make([]map[string]interface{}, 0, len(stats))
+	for _, s := range stats {
+		row := map[string]interface{}{
+			"countryCode":                        s.CountryCode,
+			"dateUpdated":                        s.DateUpdated.Format("2006-01-02"),
+			"totalVaccinations":                  nullableInt(s.TotalVaccinations),
+			"totalVaccinationsPer100":            nullableFloat(s.TotalVaccinationsPer100),
+			"personsVaccinated1PlusDose":         nullableInt(s.PersonsVaccinated1PlusDose),
+			"personsVaccinated1PlusDosePer100":   nullableFloat(s.PersonsVaccinated1PlusDosePer100),
+			"personsFullyVaccinated":             nullableInt(s.PersonsFullyVaccinated),
+			"personsFullyVaccinatedPer100":       nullableFloat(s.PersonsFullyVaccinatedPer100),
+			"personsBoosterAdditionalDose":       nullableInt(s.PersonsBoosterAdditionalDose),
+			"personsBoosterAdditionalDosePer100": nullableFloat(s.PersonsBoosterAdditionalDosePer100),
+		}
+		row["fingerprint"] = fingerprint(row)
+		rows = append(rows, row)
+	}
+
+	batchStats, changed, err := writeBatch(ctx, r.driver,
+		`UNWIND $rows AS row
+         MATCH (c:Country {code: row.countryCode})
+         MERGE (date:Date {date: date(row.dateUpdated)})
+         MERGE (v:VaccinationStats {countryCode: row.countryCode, date: date(row.dateUpdated)})
+         WITH c, date, v, row,
+              v.fingerprint IS NULL AS inserted,
+              coalesce(v.fingerprint = row.fingerprint, false) AS unchanged
+         FOREACH (_ IN CASE WHEN unchanged THEN [] ELSE [1] END |
+             SET v.totalVaccinations = row.totalVaccinations,
+                 v.totalVaccinationsPer100 = row.totalVaccinationsPer100,
+                 v.personsVaccinated1PlusDose = row.personsVaccinated1PlusDose,
+                 v.personsVaccinated1PlusDosePer100 = row.personsVaccinated1PlusDosePer100,
+                 v.personsFullyVaccinated = row.personsFullyVaccinated,
+                 v.personsFullyVaccinatedPer100 = row.personsFullyVaccinatedPer100,
+                 v.personsBoosterAdditionalDose = row.personsBoosterAdditionalDose,
+                 v.personsBoosterAdditionalDosePer100 = row.personsBoosterAdditionalDosePer100,
+                 v.fingerprint = row.fingerprint
+         )
+         MERGE (c)-[:VACCINATED_ON]->(v)
+         MERGE (v)-[:ON_DATE]->(date)
+         RETURN sum(CASE WHEN inserted THEN 1 ELSE 0 END) AS inserted,
+                sum(CASE WHEN NOT inserted AND NOT unchanged THEN 1 ELSE 0 END) AS updated,
+                sum(CASE WHEN unchanged THEN 1 ELSE 0 END) AS unchanged,
+                collect(CASE WHEN unchanged THEN null ELSE {countryCode: row.countryCode, date: row.dateUpdated} END) AS changed`,
+		rows,
+	)
+	if err != nil {
+		log.Printf("Error upserting batch of %d VaccinationStats: %v", len(rows), err)
+	}
+	return batchStats, changed, err
+}
+
+// DeleteUnkeyedVaccinationStats removes VaccinationStats nodes written before
+// stats were keyed by country and date.
+func (r *VaccinationStatsRepository) DeleteUnkeyedVaccinationStats(ctx context.Context) (int, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
-	_, err := session.Run(ctx,
-		`MATCH (c:Country {code: $countryCode})
-         MERGE (date:Date {date: date($dateUpdated)})
-         CREATE (v:VaccinationStats {
-             totalVaccinations: $totalVaccinations,
-             totalVaccinationsPer100: $totalVaccinationsPer100,
-             personsVaccinated1PlusDose: $personsVaccinated1PlusDose,
-             personsVaccinated1PlusDosePer100: $personsVaccinated1PlusDosePer100,
-             personsFullyVaccinated: $personsFullyVaccinated,
-             personsFullyVaccinatedPer100: $personsFullyVaccinatedPer100,
-             personsBoosterAdditionalDose: $personsBoosterAdditionalDose,
-             personsBoosterAdditionalDosePer100: $personsBoosterAdditionalDosePer100
-         })
-         MERGE (c)-[:VACCINATED_ON]->(v)
-         MERGE (v)-[:ON_DATE]->(date)`,
-		map[string]interface{}{
-			"countryCode":                        countryCode,
-			"dateUpdated":                        dateUpdated,
-			"totalVaccinations":                  totalVaccinations,
-			"totalVaccinationsPer100":            totalVaccinationsPer100,
-			"personsVaccinated1PlusDose":         personsVaccinated1PlusDose,
-			"personsVaccinated1PlusDosePer100":   personsVaccinated1PlusDosePer100,
-			"personsFullyVaccinated":             personsFullyVaccinated,
-			"personsFullyVaccinatedPer100":       personsFullyVaccinatedPer100,
-			"personsBoosterAdditionalDose":       personsBoosterAdditionalDose,
-			"personsBoosterAdditionalDosePer100": personsBoosterAdditionalDosePer100,
-		})
+	result, err := session.Run(ctx,
+		`MATCH (v:VaccinationStats)
+         WHERE v.countryCode IS NULL OR v.date IS NULL
+         CALL { WITH v DETACH DELETE v } IN TRANSACTIONS OF 10000 ROWS`,
+		nil)
+	if err != nil {
+		log.Printf("Error deleting unkeyed VaccinationStats: %v", err)
+		return 0, err
+	}
+
+	summary, err := result.Consume(ctx)
 	if err != nil {
-		log.Printf("Error creating VaccinationStats for country %s on date %s: %v", countryCode, dateUpdated.Format("2006-01-02"), err)
+		return 0, err
 	}
-	return err
+	return summary.Counters().NodesDeleted(), nil
 }
@@ -12,47 +12,209 @@ type VaccineRepository struct {
 	driver neo4j.DriverWithContext
 }
 
+// VaccineRow is one vaccination-metadata.csv row: a product and what one
+// country reported about its use. The dates and the other per-country fields
+// are stored on that country's USES relationship.
+type VaccineRow struct {
+	CountryCode       string
+	Product           string
+	VaccineName       string
+	Company           string
+	AuthorizationDate *time.Time
+	StartDate         *time.Time
+	EndDate           *time.Time
+	Comment           string
+	DataSource        string
+}
+
 func NewVaccineRepository(driver neo4j.DriverWithContext) *VaccineRepository {
 	return &VaccineRepository{driver: driver}
 }
 
-func (r *VaccineRepository) CreateVaccine(ctx context.Context, product string, vaccineName string, company string, authorizationDate time.Time, startDate time.Time) error {
+// CreateVaccines merges each vaccine and its company, and links the vaccine
+// to the company that produces it and to the country that uses it. A vaccine
+// that changed company is unlinked from the earlier one, which is deleted
+// once it produces nothing else. Rows whose country does not exist still
+// create the vaccine. Fields that were not reported are left unset on the
+// relationship. It returns the rows whose country relationship it inserted or
+// updated.
+func (r *VaccineRepository) CreateVaccines(ctx context.Context, vaccines []VaccineRow) (BatchStats, []ChangedRow, error) {
+	rows := make([]map[string]interface{}, 0, len(vaccines))
+	for _, v := range vaccines {
+		row := map[string]interface{}{
+			"countryCode":       v.CountryCode,
+			"product":           v.Product,
+			"vaccineName":       v.VaccineName,
+			"company":           v.Company,
+			"authorizationDate": nullableDate(v.AuthorizationDate),
+			"startDate":         nullableDate(v.StartDate),
+			"endDate":           nullableDate(v.EndDate),
+			"comment":           nullIfEmpty(v.Comment),
+			"dataSource":        nullIfEmpty(v.DataSource),
+		}
+		row["fingerprint"] = fingerprint(row)
+		rows = append(rows, row)
+	}
+
+	stats, changed, err := writeBatch(ctx, r.driver,
+		`UNWIND $rows AS row
+         MERGE (v:Vaccine {product: row.product})
+         SET v.vaccine = row.vaccineName, v.company = row.company
+         MERGE (co:Company {name: row.company})
+         MERGE (co)-[:PRODUCES]->(v)
+         WITH v, row
+         CALL {
+             WITH v, row
+             MATCH (other:Company)-[old:PRODUCES]->(v)
+             WHERE other.name <> row.company
+             DELETE old
+             WITH other
+             WHERE NOT EXISTS { (other)-[:PRODUCES]->() }
+             DETACH DELETE other
+         }
+         WITH v, row
+         MATCH (c:Country {code: row.countryCode})
+         MERGE (c)-[u:USES]->(v)
+         WITH u, row,
+              u.fingerprint IS NULL AS inserted,
+              coalesce(u.fingerprint = row.fingerprint, false) AS unchanged
+         FOREACH (_ IN CASE WHEN unchanged THEN [] ELSE [1] END |
+             SET u.authorizationDate = date(row.authorizationDate),
+                 u.startDate = date(row.startDate),
+                 u.endDate = date(row.endDate),
+                 u.comment = row.comment,
+                 u.dataSource = row.dataSource,
+                 u.fingerprint = row.fingerprint
+         )
+         RETURN sum(CASE WHEN inserted THEN 1 ELSE 0 END) AS inserted,
+                sum(CASE WHEN NOT inserted AND NOT unchanged THEN 1 ELSE 0 END) AS updated,
+                sum(CASE WHEN unchanged THEN 1 ELSE 0 END) AS unchanged,
+                collect(CASE WHEN unchanged THEN null ELSE {countryCode: row.countryCode} END) AS changed`,
+		rows,
+	)
+	if err != nil {
+		log.Printf("Error creating batch of %d vaccines: %v", len(rows), err)
+	}
+	return stats, changed, err
+}
+
+// VaccinePlatformRow is one vaccine-platforms.csv row: how a product works,
+// how many doses a full course takes and whether it holds a WHO Emergency Use
+// Listing. Empty or nil fields were not reported.
+type VaccinePlatformRow struct {
+	Product  string
+	Platform string
+	Doses    *int
+	WHOEUL   string
+}
+
+// SetVaccinePlatforms stores the platform, doses and WHO EUL status of each
+// row on the Vaccine node of its product. Rows whose product was not loaded
+// are skipped, and fields that were not reported are removed. It returns a
+// row for every country that uses a product whose fields it changed.
+func (r *VaccineRepository) SetVaccinePlatforms(ctx context.Context, platforms []VaccinePlatformRow) (BatchStats, []ChangedRow, error) {
+	rows := make([]map[string]interface{}, 0, len(platforms))
+	for _, p := range platforms {
+		row := map[string]interface{}{
+			"product":  p.Product,
+			"platform": p.Platform,
+			"doses":    nullableInt(p.Doses),
+			"whoEul":   nullIfEmpty(p.WHOEUL),
+		}
+		row["fingerprint"] = fingerprint(row)
+		rows = append(rows, row)
+	}
+
+	stats, changed, err := writeBatch(ctx, r.driver,
+		`UNWIND $rows AS row
+         MATCH (v:Vaccine {product: row.product})
+         WITH v, row, coalesce(v.platformFingerprint = row.fingerprint, false) AS unchanged
+         FOREACH (_ IN CASE WHEN unchanged THEN [] ELSE [1] END |
+             SET v.platform = row.platform,
+                 v.doses = row.doses,
+                 v.whoEul = row.whoEul,
+                 v.platformFingerprint = row.fingerprint
+         )
+         WITH unchanged,
+              CASE WHEN unchanged THEN [] ELSE COLLECT { MATCH (c:Country)-[:USES]->(v) RETURN c.code } END AS countries
+         RETURN sum(CASE WHEN unchanged THEN 0 ELSE 1 END) AS updated,
+                sum(CASE WHEN unchanged THEN 1 ELSE 0 END) AS unchanged,
+                reduce(flat = [], codes IN collect(countries) | flat + [code IN codes | {countryCode: code}]) AS changed`,
+		rows,
+	)
+	if err != nil {
+		log.Printf("Error setting platforms of batch of %d vaccines: %v", len(rows), err)
+	}
+	return stats, changed, err
+}
+
+// ProductsWithoutPlatform returns the products of the Vaccine nodes that have
+// no platform, ordered by product.
+func (r *VaccineRepository) ProductsWithoutPlatform(ctx context.Context) ([]string, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx,
+		`MATCH (v:Vaccine)
+         WHERE v.platform IS NULL
+         RETURN v.product AS product
+         ORDER BY product`,
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []string
+	for result.Next(ctx) {
+		product, _, _ := neo4j.GetRecordValue[string](result.Record(), "product")
+		products = append(products, product)
+	}
+	return products, result.Err()
+}
+
+// DeleteIdleCompanies removes the Company nodes that produce no vaccine, left
+// behind when a vaccine changed company before CreateVaccines deleted them.
+func (r *VaccineRepository) DeleteIdleCompanies(ctx context.Context) (int, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
-	_, err := session.Run(ctx,
-		`MERGE (v:Vaccine {product: $product})
-         SET v.vaccine = $vaccineName, v.company = $company
-         MERGE (authDate:Date {date: date($authorizationDate)})
-         MERGE (startDate:Date {date: date($startDate)})
-         MERGE (v)-[:AUTHORIZATION_ON]->(authDate)
-         MERGE (v)-[:STARTED_ON]->(startDate)`,
-		map[string]interface{}{
-			"product":           product,
-			"vaccineName":       vaccineName,
-			"company":           company,
-			"authorizationDate": authorizationDate,
-			"startDate":         startDate,
-		})
+	result, err := session.Run(ctx,
+		`MATCH (co:Company)
+         WHERE NOT EXISTS { (co)-[:PRODUCES]->() }
+         DETACH DELETE co`,
+		nil)
+	if err != nil {
+		log.Printf("Error deleting idle companies: %v", err)
+		return 0, err
+	}
+
+	summary, err := result.Consume(ctx)
 	if err != nil {
-		log.Printf("Error creating vaccine %s: %v", product, err)
+		return 0, err
 	}
-	return err
+	return summary.Counters().NodesDeleted(), nil
 }
 
-func (r *VaccineRepository) CreateCountryUsesVaccine(ctx context.Context, countryCode string, product string) error {
+// DeleteVaccineDateLinks removes the STARTED_ON and AUTHORIZATION_ON
+// relationships older loads hung off the shared Vaccine nodes, mixing the
+// dates of every country. The dates now live on each country's USES
+// relationship.
+func (r *VaccineRepository) DeleteVaccineDateLinks(ctx context.Context) (int, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
-	_, err := session.Run(ctx,
-		`MATCH (c:Country {code: $countryCode}), (v:Vaccine {product: $product})
-         MERGE (c)-[:USES]->(v)`,
-		map[string]interface{}{
-			"countryCode": countryCode,
-			"product":     product,
-		})
+	result, err := session.Run(ctx,
+		`MATCH (:Vaccine)-[link:STARTED_ON|AUTHORIZATION_ON]->(:Date)
+         DELETE link`,
+		nil)
+	if err != nil {
+		log.Printf("Error deleting vaccine date links: %v", err)
+		return 0, err
+	}
+
+	summary, err := result.Consume(ctx)
 	if err != nil {
-		log.Printf("Error creating USES relationship between country %s and vaccine %s: %v", countryCode, product, err)
+		return 0, err
 	}
-	return err
+	return summary.Counters().RelationshipsDeleted(), nil
 }
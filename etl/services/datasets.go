@@ -0,0 +1,116 @@
+package services
+
+import (
+	"github.com/thalesmacedo1/covid-etl/utils"
+)
+
+// Column names of vaccination-data.csv
+const (
+	colCountry                          = "COUNTRY"
+	colISO3                             = "ISO3"
+	colWHORegion                        = "WHO_REGION"
+	colDataSource                       = "DATA_SOURCE"
+	colDateUpdated                      = "DATE_UPDATED"
+	colTotalVaccinations                = "TOTAL_VACCINATIONS"
+	colPersonsVaccinated1PlusDose       = "PERSONS_VACCINATED_1PLUS_DOSE"
+	colTotalVaccinationsPer100          = "TOTAL_VACCINATIONS_PER100"
+	colPersonsVaccinated1PlusDosePer100 = "PERSONS_VACCINATED_1PLUS_DOSE_PER100"
+	colPersonsLastDose                  = "PERSONS_LAST_DOSE"
+	colPersonsLastDosePer100            = "PERSONS_LAST_DOSE_PER100"
+	colVaccinesUsed                     = "VACCINES_USED"
+	colFirstVaccineDate                 = "FIRST_VACCINE_DATE"
+	colNumberVaccinesTypesUsed          = "NUMBER_VACCINES_TYPES_USED"
+	colPersonsBoosterAddDose            = "PERSONS_BOOSTER_ADD_DOSE"
+	colPersonsBoosterAddDosePer100      = "PERSONS_BOOSTER_ADD_DOSE_PER100"
+)
+
+var vaccinationDataColumns = []utils.Column{
+	{Name: colCountry, Required: true},
+	{Name: colISO3, Required: true},
+	{Name: colWHORegion, Required: true},
+	{Name: colDateUpdated, Required: true},
+	{Name: colTotalVaccinations},
+	{Name: colPersonsVaccinated1PlusDose},
+	{Name: colTotalVaccinationsPer100},
+	{Name: colPersonsVaccinated1PlusDosePer100},
+	{Name: colPersonsLastDose},
+	{Name: colPersonsLastDosePer100},
+	{Name: colPersonsBoosterAddDose},
+	{Name: colPersonsBoosterAddDosePer100},
+	// Known columns the loader does not read
+	{Name: colDataSource},
+	{Name: colVaccinesUsed},
+	{Name: colFirstVaccineDate},
+	{Name: colNumberVaccinesTypesUsed},
+}
+
+// Column names of vaccination-metadata.csv
+const (
+	colMetadataISO3   = "ISO3"
+	colProductName    = "PRODUCT_NAME"
+	colVaccineName    = "VACCINE_NAME"
+	colCompanyName    = "COMPANY_NAME"
+	colAuthorization  = "AUTHORIZATION_DATE"
+	colStartDate      = "START_DATE"
+	colEndDate        = "END_DATE"
+	colComment        = "COMMENT"
+	colMetadataSource = "DATA_SOURCE"
+)
+
+var vaccinationMetadataColumns = []utils.Column{
+	{Name: colMetadataISO3, Required: true},
+	{Name: colProductName, Required: true},
+	{Name: colVaccineName, Required: true},
+	{Name: colCompanyName, Required: true},
+	{Name: colAuthorization},
+	{Name: colStartDate},
+	{Name: colEndDate},
+	{Name: colComment},
+	{Name: colMetadataSource},
+}
+
+// Column names of vaccine-platforms.csv, the reference mapping maintained
+// with the project that describes each product of vaccination-metadata.csv
+const (
+	colPlatformProduct = "PRODUCT_NAME"
+	colPlatform        = "PLATFORM"
+	colPlatformDoses   = "DOSES"
+	colWHOEUL          = "WHO_EUL"
+)
+
+var vaccinePlatformColumns = []utils.Column{
+	{Name: colPlatformProduct, Required: true},
+	{Name: colPlatform, Required: true},
+	{Name: colPlatformDoses},
+	{Name: colWHOEUL},
+}
+
+// Values the PLATFORM and WHO_EUL columns accept
+var (
+	vaccinePlatforms = []string{"mrna", "viral_vector", "inactivated", "protein_subunit", "dna", "other"}
+	whoEULStatuses   = []string{"listed", "not_listed"}
+)
+
+// Column names of WHO-COVID-19-global-data.csv
+const (
+	colDateReported     = "DATE_REPORTED"
+	colCountryCode      = "COUNTRY_CODE"
+	colCountryName      = "COUNTRY"
+	colCovidWHORegion   = "WHO_REGION"
+	colNewCases         = "NEW_CASES"
+	colCumulativeCases  = "CUMULATIVE_CASES"
+	colNewDeaths        = "NEW_DEATHS"
+	colCumulativeDeaths = "CUMULATIVE_DEATHS"
+)
+
+var covidDataColumns = []utils.Column{
+	{Name: colDateReported, Required: true},
+	{Name: colCountryCode, Required: true},
+	{Name: colCountryName},
+	{Name: colNewCases, Required: true},
+	{Name: colCumulativeCases, Required: true},
+	{Name: colNewDeaths, Required: true},
+	{Name: colCumulativeDeaths, Required: true},
+	// Known columns the loader does not read
+	{Name: colCovidWHORegion},
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
-	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -10,16 +12,30 @@ import (
 	"github.com/thalesmacedo1/covid-etl/config"
 	"github.com/thalesmacedo1/covid-etl/repositories"
 	"github.com/thalesmacedo1/covid-etl/utils"
+	"github.com/thalesmacedo1/covid-shared/events"
+	"github.com/thalesmacedo1/covid-shared/migrations"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/redis/go-redis/v9"
 )
 
 type ETLService struct {
 	driver               neo4j.DriverWithContext
 	countryRepo          *repositories.CountryRepository
+	dateRepo             *repositories.DateRepository
 	vaccineRepo          *repositories.VaccineRepository
 	covidStatsRepo       *repositories.CovidStatsRepository
 	vaccinationStatsRepo *repositories.VaccinationStatsRepository
+	batchSize            int
+
+	rejects        *utils.RejectWriter
+	maxRejectRatio float64
+
+	deleteNonCanonicalCountries bool
+
+	// Where the import completed event goes, nil without Redis
+	events   *redis.Client
+	imported *importTracker
 }
 
 func NewETLService() (*ETLService, error) {
@@ -31,22 +47,57 @@ func NewETLService() (*ETLService, error) {
 		return nil, err
 	}
 
-	return &ETLService{
-		driver:               driver,
-		countryRepo:          repositories.NewCountryRepository(driver),
-		vaccineRepo:          repositories.NewVaccineRepository(driver),
-		covidStatsRepo:       repositories.NewCovidStatsRepository(driver),
-		vaccinationStatsRepo: repositories.NewVaccinationStatsRepository(driver),
-	}, nil
+	service := &ETLService{
+		driver:                      driver,
+		countryRepo:                 repositories.NewCountryRepository(driver),
+		dateRepo:                    repositories.NewDateRepository(driver),
+		vaccineRepo:                 repositories.NewVaccineRepository(driver),
+		covidStatsRepo:              repositories.NewCovidStatsRepository(driver),
+		vaccinationStatsRepo:        repositories.NewVaccinationStatsRepository(driver),
+		batchSize:                   config.Settings.BatchSize,
+		maxRejectRatio:              config.Settings.MaxRejectRatio,
+		deleteNonCanonicalCountries: config.Settings.DeleteNonCanonicalCountries,
+		imported:                    newImportTracker(),
+	}
+	if config.Settings.RedisAddr != "" {
+		service.events = newEventsClient(config.Settings.RedisAddr, config.Settings.RedisPassword, config.Settings.RedisDB)
+	}
+	return service, nil
 }
 
 func (s *ETLService) Run(ctx context.Context) error {
 	defer s.driver.Close(ctx)
+	if s.events != nil {
+		defer s.events.Close()
+	}
+
+	// Drop data left behind by older loads: stats that are not keyed by
+	// country and date, vaccine dates shared by every country and the
+	// 9999-12-31 placeholder for missing dates. Node keys cannot be created
+	// while they exist. Countries outside the ISO 3166 identity are only
+	// deleted when asked to
+	if err := s.deleteLegacyData(ctx); err != nil {
+		return err
+	}
 
-	// // Create constraints
-	// if err := s.createConstraints(ctx); err != nil {
-	// 	return err
-	// }
+	// Apply schema migrations
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+
+	// Rejected and coerced rows of every file go to one reject file
+	rejectFile, err := os.Create(config.Settings.RejectFile)
+	if err != nil {
+		return fmt.Errorf("failed to create reject file: %w", err)
+	}
+	defer rejectFile.Close()
+
+	s.rejects, err = utils.NewRejectWriter(rejectFile, config.Settings.RejectFormat)
+	if err != nil {
+		return err
+	}
+	// Keep the rejects written so far when a file fails
+	defer s.rejects.Flush()
 
 	// Process CSV files
 	if err := s.processCountries(ctx, filepath.Join("data", "vaccination-data.csv")); err != nil {
@@ -55,175 +106,435 @@ func (s *ETLService) Run(ctx context.Context) error {
 	if err := s.processVaccines(ctx, filepath.Join("data", "vaccination-metadata.csv")); err != nil {
 		return err
 	}
+	if err := s.processVaccinePlatforms(ctx, filepath.Join("data", "vaccine-platforms.csv")); err != nil {
+		return err
+	}
 	if err := s.processCovidStats(ctx, filepath.Join("data", "WHO-COVID-19-global-data.csv")); err != nil {
 		return err
 	}
 
+	if err := s.rejects.Flush(); err != nil {
+		return fmt.Errorf("failed to write reject file: %w", err)
+	}
+
+	s.publishImportCompleted(ctx)
 	return nil
 }
 
-// func (s *ETLService) createConstraints(ctx context.Context) error {
-// 	session := s.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-// 	defer session.Close(ctx)
-
-// 	constraints := []string{
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Vaccine) REQUIRE (n.product) IS NODE KEY`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Date) REQUIRE (n.date) IS NODE KEY`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Region) REQUIRE (n.name) IS NODE KEY`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Country) REQUIRE (n.code) IS NODE KEY`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Vaccine) REQUIRE exists(n.company)`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Vaccine) REQUIRE exists(n.vaccine)`,
-// 		`CREATE CONSTRAINT IF NOT EXISTS FOR (n:Country) REQUIRE exists(n.name)`,
-// 	}
-
-// 	for _, constraint := range constraints {
-// 		if _, err := session.Run(ctx, constraint, nil); err != nil {
-// 			return fmt.Errorf("Error creating constraint %s: %v", constraint, err)
-// 		}
-// 	}
-// 	log.Println("Constraints created successfully.")
-// 	return nil
-// }
+func (s *ETLService) deleteLegacyData(ctx context.Context) error {
+	if err := s.cleanUpNonCanonicalCountries(ctx); err != nil {
+		return err
+	}
 
-func (s *ETLService) processCountries(ctx context.Context, filename string) error {
-	file, err := os.Open(filename)
+	deleted, err := s.covidStatsRepo.DeleteUnkeyedCovidStats(ctx)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.Comma = ';'
+	if deleted > 0 {
+		log.Printf("Deleted %d unkeyed CovidStats nodes", deleted)
+	}
 
-	records, err := reader.ReadAll()
+	deleted, err = s.vaccinationStatsRepo.DeleteUnkeyedVaccinationStats(ctx)
 	if err != nil {
 		return err
 	}
+	if deleted > 0 {
+		log.Printf("Deleted %d unkeyed VaccinationStats nodes", deleted)
+	}
 
-	for _, record := range records[1:] {
-		country := record[0]
-		code := record[1]
-		region := record[2]
-		dateUpdated := record[4]
+	deleted, err = s.vaccineRepo.DeleteVaccineDateLinks(ctx)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d date relationships of Vaccine nodes", deleted)
+	}
 
-		// Create country entry
-		if err := s.countryRepo.CreateCountry(ctx, country, code, region); err != nil {
-			continue
-		}
+	deleted, err = s.vaccineRepo.DeleteIdleCompanies(ctx)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d Company nodes that produce no vaccine", deleted)
+	}
 
-		// Create vaccination stats
-		dateParsed := utils.ParseDate(dateUpdated)
-		totalVaccinations := utils.ParseInt(record[5])
-		totalVaccinationsPer100 := utils.ParseInt(record[7])
-		personsVaccinated1PlusDose := utils.ParseInt(record[6])
-		personsVaccinated1PlusDosePer100 := utils.ParseInt(record[8])
-		personsLastDose := utils.ParseInt(record[9])
-		personsLastDosePer100 := utils.ParseInt(record[10])
-		personsBoosterAddDose := utils.ParseInt(record[14])
-		personsBoosterAddDosePer100 := utils.ParseInt(record[15])
-
-		if err := s.vaccinationStatsRepo.CreateVaccinationStats(
-			ctx,
-			code,
-			totalVaccinations,
-			totalVaccinationsPer100,
-			personsVaccinated1PlusDose,
-			personsVaccinated1PlusDosePer100,
-			personsLastDose,
-			personsLastDosePer100,
-			personsBoosterAddDose,
-			personsBoosterAddDosePer100,
-			dateParsed); err != nil {
-			continue
-		}
+	deleted, err = s.dateRepo.DeleteSentinelDates(ctx)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d nodes dated 9999-12-31", deleted)
 	}
+
 	return nil
 }
 
-func (s *ETLService) processVaccines(ctx context.Context, filename string) error {
-	file, err := os.Open(filename)
+// cleanUpNonCanonicalCountries reports the countries that were not created
+// from the ISO 3166 table and, with ETL_DELETE_NON_CANONICAL_COUNTRIES set,
+// deletes them with their stats.
+func (s *ETLService) cleanUpNonCanonicalCountries(ctx context.Context) error {
+	countries, err := s.countryRepo.NonCanonicalCountries(ctx)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	if len(countries) == 0 {
+		return nil
+	}
 
-	reader := csv.NewReader(file)
-	reader.Comma = ';' // Set the correct delimiter
+	if !s.deleteNonCanonicalCountries {
+		log.Printf("Found %d countries outside the ISO 3166 table, set ETL_DELETE_NON_CANONICAL_COUNTRIES=true to delete them: %v",
+			len(countries), countries)
+		return nil
+	}
 
-	records, err := reader.ReadAll()
+	deleted, err := s.countryRepo.DeleteNonCanonicalCountries(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Deleted %d non-canonical countries and their stats, %d nodes in all: %v", len(countries), deleted, countries)
+	return nil
+}
+
+// migrate brings the graph schema up to date. On Community edition the
+// property existence rules are enforced by requireProperties instead.
+func (s *ETLService) migrate(ctx context.Context) error {
+	runner, err := migrations.NewRunner(s.driver)
 	if err != nil {
 		return err
 	}
 
-	for _, record := range records[1:] { // Skip header
+	result, err := runner.Run(ctx)
+	if err != nil {
+		return err
+	}
 
-		countryCode := record[0]
-		product := record[1]
-		vaccineName := record[2]
-		company := record[3]
-		authorizationDateStr := record[4]
-		startDateStr := record[5]
+	for _, migration := range result.Applied {
+		log.Printf("Applied schema migration %04d_%s (%s edition)", migration.Version, migration.Name, result.Edition)
+	}
+	return nil
+}
 
-		// Parse dates
-		authorizationDate := utils.ParseDate(authorizationDateStr)
-		startDate := utils.ParseDate(startDateStr)
+func (s *ETLService) processCountries(ctx context.Context, filename string) error {
+	return s.streamFile(ctx, filename, vaccinationDataColumns, func(report *fileReport, rows []sourceRow) batchWrite {
+		countries := make([]repositories.CountryRow, 0, len(rows))
+		vaccinationStats := make([]repositories.VaccinationStatsRow, 0, len(rows))
+
+		for _, row := range rows {
+			// A few country names, such as "China; Hong Kong SAR", hold the delimiter unquoted
+			row = report.rejoinSplit(row, colCountry, colISO3)
+			name := report.get(row, colCountry)
+
+			country, ok := report.country(row, colISO3)
+			if !ok {
+				continue
+			}
+
+			if !report.requireProperties(row, "Country", map[string]interface{}{"code": country.Alpha3, "name": name}) {
+				continue
+			}
+
+			dateUpdated, ok := report.requiredDate(row, colDateUpdated)
+			if !ok {
+				continue
+			}
+
+			countries = append(countries, repositories.CountryRow{
+				Name:    name,
+				Code:    country.Alpha3,
+				ISO2:    country.Alpha2,
+				Numeric: country.Numeric,
+				Region:  report.get(row, colWHORegion),
+			})
+
+			vaccinationStats = append(vaccinationStats, repositories.VaccinationStatsRow{
+				CountryCode:                        country.Alpha3,
+				DateUpdated:                        dateUpdated,
+				TotalVaccinations:                  report.int(row, colTotalVaccinations),
+				TotalVaccinationsPer100:            report.float(row, colTotalVaccinationsPer100),
+				PersonsVaccinated1PlusDose:         report.int(row, colPersonsVaccinated1PlusDose),
+				PersonsVaccinated1PlusDosePer100:   report.float(row, colPersonsVaccinated1PlusDosePer100),
+				PersonsFullyVaccinated:             report.int(row, colPersonsLastDose),
+				PersonsFullyVaccinatedPer100:       report.float(row, colPersonsLastDosePer100),
+				PersonsBoosterAdditionalDose:       report.int(row, colPersonsBoosterAddDose),
+				PersonsBoosterAdditionalDosePer100: report.float(row, colPersonsBoosterAddDosePer100),
+			})
+		}
 
-		// Create vaccine
-		err := s.vaccineRepo.CreateVaccine(ctx,
-			product, vaccineName, company, authorizationDate, startDate)
-		if err != nil {
-			log.Printf("Error creating vaccine %s: %v", product, err)
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			// Countries go first so the vaccination stats can MATCH them
+			stats, err := s.countryRepo.CreateCountries(ctx, countries)
+			if err != nil {
+				return stats, err
+			}
+
+			vaccinationBatchStats, changed, err := s.vaccinationStatsRepo.UpsertVaccinationStats(ctx, vaccinationStats)
+			s.imported.recordChanged(events.DatasetVaccinations, changed)
+			stats.Add(vaccinationBatchStats)
+			// Both writes cover the same source rows
+			stats.Rows = len(rows)
+			return stats, err
+		}
+	})
+}
+
+func (s *ETLService) processVaccines(ctx context.Context, filename string) error {
+	return s.streamFile(ctx, filename, vaccinationMetadataColumns, func(report *fileReport, rows []sourceRow) batchWrite {
+		vaccines := make([]repositories.VaccineRow, 0, len(rows))
+
+		for _, row := range rows {
+			product := report.get(row, colProductName)
+			vaccineName := report.get(row, colVaccineName)
+			company := report.get(row, colCompanyName)
+
+			country, ok := report.country(row, colMetadataISO3)
+			if !ok {
+				continue
+			}
+
+			if !report.requireProperties(row, "Vaccine", map[string]interface{}{"product": product, "vaccine": vaccineName, "company": company}) {
+				continue
+			}
+
+			vaccines = append(vaccines, repositories.VaccineRow{
+				CountryCode:       country.Alpha3,
+				Product:           product,
+				VaccineName:       vaccineName,
+				Company:           company,
+				AuthorizationDate: report.date(row, colAuthorization),
+				StartDate:         report.date(row, colStartDate),
+				EndDate:           report.date(row, colEndDate),
+				Comment:           report.get(row, colComment),
+				DataSource:        report.get(row, colMetadataSource),
+			})
+		}
+
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			stats, changed, err := s.vaccineRepo.CreateVaccines(ctx, vaccines)
+			s.imported.recordChanged(events.DatasetVaccines, changed)
+			return stats, err
+		}
+	})
+}
+
+// processVaccinePlatforms enriches the vaccines loaded from the metadata file
+// with the reference mapping of their platforms. Products whose platform
+// fields changed are recorded as a vaccines import for the countries that
+// use them.
+func (s *ETLService) processVaccinePlatforms(ctx context.Context, filename string) error {
+	err := s.streamFile(ctx, filename, vaccinePlatformColumns, func(report *fileReport, rows []sourceRow) batchWrite {
+		platforms := readVaccinePlatforms(report, rows)
+
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			stats, changed, err := s.vaccineRepo.SetVaccinePlatforms(ctx, platforms)
+			if err == nil && stats.Updated > 0 {
+				s.imported.recordDataset(events.DatasetVaccines)
+			}
+			s.imported.recordChanged(events.DatasetVaccines, changed)
+			return stats, err
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	unmapped, err := s.vaccineRepo.ProductsWithoutPlatform(ctx)
+	if err != nil {
+		return err
+	}
+	if len(unmapped) > 0 {
+		log.Printf("%s: %d products have no platform, add them to the mapping: %v", filepath.Base(filename), len(unmapped), unmapped)
+	}
+	return nil
+}
+
+// readVaccinePlatforms reads the rows of the platform mapping, rejecting those
+// without a product or a known platform.
+func readVaccinePlatforms(report *fileReport, rows []sourceRow) []repositories.VaccinePlatformRow {
+	platforms := make([]repositories.VaccinePlatformRow, 0, len(rows))
+
+	for _, row := range rows {
+		product := report.get(row, colPlatformProduct)
+		if product == "" {
+			report.reject(row, colPlatformProduct, "product is empty")
 			continue
 		}
 
-		// Create relationship between country and vaccine
-		err = s.vaccineRepo.CreateCountryUsesVaccine(ctx, countryCode, product)
-		if err != nil {
-			log.Printf("Error creating relationship between country %s and vaccine %s: %v", countryCode, product, err)
+		platform, ok := report.requiredChoice(row, colPlatform, vaccinePlatforms)
+		if !ok {
 			continue
 		}
+
+		platforms = append(platforms, repositories.VaccinePlatformRow{
+			Product:  product,
+			Platform: platform,
+			Doses:    report.int(row, colPlatformDoses),
+			WHOEUL:   report.choice(row, colWHOEUL, whoEULStatuses),
+		})
 	}
-	return nil
+	return platforms
 }
 
 func (s *ETLService) processCovidStats(ctx context.Context, filename string) error {
-	file, err := os.Open(filename)
+	return s.streamFile(ctx, filename, covidDataColumns, func(report *fileReport, rows []sourceRow) batchWrite {
+		covidStats := make([]repositories.CovidStatsRow, 0, len(rows))
+
+		for _, row := range rows {
+			// The WHO file keys countries by their alpha-2 code
+			country, ok := report.country(row, colCountryCode)
+			if !ok {
+				continue
+			}
+
+			date, ok := report.requiredDate(row, colDateReported)
+			if !ok {
+				continue
+			}
+
+			// The country is created here when the vaccination data does not list it
+			countryName := report.get(row, colCountryName)
+			if countryName == "" {
+				countryName = country.Name
+			}
+
+			covidStats = append(covidStats, repositories.CovidStatsRow{
+				Date:             date,
+				CountryCode:      country.Alpha3,
+				CountryISO2:      country.Alpha2,
+				CountryNumeric:   country.Numeric,
+				CountryName:      countryName,
+				NewCases:         report.int(row, colNewCases),
+				CumulativeCases:  report.int(row, colCumulativeCases),
+				NewDeaths:        report.int(row, colNewDeaths),
+				CumulativeDeaths: report.int(row, colCumulativeDeaths),
+			})
+		}
+
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			stats, changed, err := s.covidStatsRepo.UpsertCovidStats(ctx, covidStats)
+			s.imported.recordChanged(events.DatasetCovid, changed)
+			return stats, err
+		}
+	})
+}
+
+// sourceDelimiter separates the fields of every source file.
+const sourceDelimiter = ';'
+
+// batchWrite commits the rows a loader read from one batch.
+type batchWrite func(ctx context.Context) (repositories.BatchStats, error)
+
+// streamFile reads filename batch by batch and hands every batch to load,
+// logging what each committed transaction wrote. Column positions are taken
+// from the header row, matched against the columns the dataset declares.
+// The file is read twice: the first pass only parses it, so that when more
+// of its rows are rejected than the reject ratio allows the run fails before
+// anything is committed, and the second commits every batch.
+func (s *ETLService) streamFile(ctx context.Context, filename string, columns []utils.Column, load func(report *fileReport, rows []sourceRow) batchWrite) error {
+	discard, err := utils.NewRejectWriter(io.Discard, utils.RejectFormatNDJSON)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	checked, err := s.readFile(filename, columns, discard, load, nil)
+	if err != nil {
+		return err
+	}
+	if err := checked.checkBudget(s.maxRejectRatio); err != nil {
+		return fmt.Errorf("%w, nothing committed", err)
+	}
 
-	reader := csv.NewReader(file)
-	reader.Comma = ';' // Set the correct delimiter
+	name := filepath.Base(filename)
+	if len(checked.cols.Missing) > 0 {
+		log.Printf("%s: optional columns not present, loading them as empty: %v", name, checked.cols.Missing)
+	}
+	if len(checked.cols.Extra) > 0 {
+		log.Printf("%s: ignoring unexpected columns: %v", name, checked.cols.Extra)
+	}
 
-	records, err := reader.ReadAll()
+	var total repositories.BatchStats
+	report, err := s.readFile(filename, columns, s.rejects, load, func(batchNumber int, write batchWrite) error {
+		stats, err := write(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to write batch %d of %s: %w", batchNumber, filename, err)
+		}
+		total.Add(stats)
+
+		log.Printf("%s: batch %d committed: %v", name, batchNumber, stats)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	for _, record := range records[1:] { // Skip header
+	log.Printf("%s: loaded %v", name, total)
+	for code, rows := range report.unresolved {
+		log.Printf("%s: rejected %d rows with unknown country code %q", name, rows, code)
+	}
+	if report.rejected > 0 || report.coerced > 0 {
+		log.Printf("%s: %d of %d rows rejected, %d loaded with coerced values (see %s)", name, report.rejected, report.rows, report.coerced, config.Settings.RejectFile)
+	}
+	return nil
+}
 
-		dateStr := record[0]
-		countryCode := record[1]
-		newCasesStr := record[4]
-		cumulativeCasesStr := record[5]
-		newDeathsStr := record[6]
-		cumulativeDeathsStr := record[7]
+// readFile parses filename batch by batch with load, recording its rejects
+// in rejects, and hands the write of every batch to commit unless it is nil.
+func (s *ETLService) readFile(filename string, columns []utils.Column, rejects *utils.RejectWriter, load func(report *fileReport, rows []sourceRow) batchWrite, commit func(batchNumber int, write batchWrite) error) (*fileReport, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-		// Parse date and integers
-		date := utils.ParseDate(dateStr)
-		newCases := utils.ParseInt(newCasesStr)
-		cumulativeCases := utils.ParseInt(cumulativeCasesStr)
-		newDeaths := utils.ParseInt(newDeathsStr)
-		cumulativeDeaths := utils.ParseInt(cumulativeDeathsStr)
+	reader := utils.NewCSVBatchReader(file, sourceDelimiter, s.batchSize)
 
-		// Create COVID stats
-		err := s.covidStatsRepo.CreateCovidStats(ctx,
-			countryCode, date, newCases, cumulativeCases, newDeaths, cumulativeDeaths)
+	header, err := reader.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of %s: %w", filename, err)
+	}
+
+	cols, err := utils.MapColumns(header, columns)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected header in %s: %w", filename, err)
+	}
+
+	report := &fileReport{
+		file:       filepath.Base(filename),
+		cols:       cols,
+		rejects:    rejects,
+		unresolved: make(map[string]int),
+	}
+
+	for batchNumber := 1; ; batchNumber++ {
+		records, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			log.Printf("Error creating Covid stats for country %s on date %s: %v", countryCode, dateStr, err)
+			return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		rows := make([]sourceRow, 0, len(records))
+		for i, record := range records {
+			row := sourceRow{line: reader.Lines()[i], record: record}
+			report.rows++
+
+			// Rows wider than the header cannot be matched to its columns
+			if !cols.Fits(record) {
+				report.reject(row, "", fmt.Sprintf("row has %d fields, more than the header", len(record)))
+				continue
+			}
+			rows = append(rows, row)
+		}
+
+		write := load(report, rows)
+		if report.err != nil {
+			return nil, fmt.Errorf("failed to write reject file: %w", report.err)
+		}
+		if commit == nil {
 			continue
 		}
+		if err := commit(batchNumber, write); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+	return report, nil
 }
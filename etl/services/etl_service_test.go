@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thalesmacedo1/covid-etl/config"
+	"github.com/thalesmacedo1/covid-etl/repositories"
+	"github.com/thalesmacedo1/covid-etl/utils"
+)
+
+// streamTestFile writes content to a file and streams it in batches of
+// batchSize rows, rejecting the rows whose code is "BAD". It returns the
+// codes of the batches that were committed.
+func streamTestFile(t *testing.T, content string, batchSize int, maxRatio float64) ([][]string, error) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "test.csv")
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rejects, err := utils.NewRejectWriter(&strings.Builder{}, utils.RejectFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &ETLService{batchSize: batchSize, rejects: rejects, maxRejectRatio: maxRatio}
+
+	var committed [][]string
+	err = service.streamFile(context.Background(), filename, testColumns, func(report *fileReport, rows []sourceRow) batchWrite {
+		var codes []string
+		for _, row := range rows {
+			code := report.get(row, "CODE")
+			if code == "BAD" {
+				report.reject(row, "CODE", "bad code")
+				continue
+			}
+			codes = append(codes, code)
+		}
+
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			committed = append(committed, codes)
+			return repositories.BatchStats{Rows: len(codes)}, nil
+		}
+	})
+	return committed, err
+}
+
+func TestStreamFileRejectBudget(t *testing.T) {
+	content := "CODE;VALUE\nBRA;1\nARG;2\nBAD;3\nBAD;4\nCHL;5\n"
+
+	t.Run("Within budget", func(t *testing.T) {
+		committed, err := streamTestFile(t, content, 2, 0.5)
+		if err != nil {
+			t.Fatalf("streamFile() error = %v", err)
+		}
+		if len(committed) != 3 {
+			t.Errorf("committed %d batches; want 3", len(committed))
+		}
+	})
+
+	t.Run("Over budget commits nothing", func(t *testing.T) {
+		committed, err := streamTestFile(t, content, 2, 0.25)
+		if err == nil || !strings.Contains(err.Error(), "nothing committed") {
+			t.Fatalf("streamFile() error = %v; want nothing committed", err)
+		}
+		if len(committed) != 0 {
+			t.Errorf("committed %v; want no batch", committed)
+		}
+	})
+
+	// Like the international conveyances of the WHO file, the rejected rows
+	// are grouped together, well over the budget within their batches
+	t.Run("Grouped rejects within the file budget", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("CODE;VALUE\n")
+		rows, rejected := 4*config.DefaultBatchSize, config.DefaultBatchSize/10
+		for i := 0; i < rows; i++ {
+			code := "BRA"
+			if i >= config.DefaultBatchSize/2 && i < config.DefaultBatchSize/2+rejected {
+				code = "BAD"
+			}
+			fmt.Fprintf(&b, "%s;%d\n", code, i)
+		}
+
+		committed, err := streamTestFile(t, b.String(), config.DefaultBatchSize, config.DefaultMaxRejectRatio)
+		if err != nil {
+			t.Fatalf("streamFile() error = %v", err)
+		}
+		loaded := 0
+		for _, codes := range committed {
+			loaded += len(codes)
+		}
+		if len(committed) != 4 || loaded != rows-rejected {
+			t.Errorf("committed %d batches with %d rows; want 4 with %d", len(committed), loaded, rows-rejected)
+		}
+	})
+}
+
+func TestReadVaccinePlatforms(t *testing.T) {
+	report, buf := newColumnsReport(t, []string{colPlatformProduct, colPlatform, colPlatformDoses, colWHOEUL}, vaccinePlatformColumns)
+	row := func(line int, values ...string) sourceRow {
+		return sourceRow{line: line, record: values}
+	}
+
+	platforms := readVaccinePlatforms(report, []sourceRow{
+		row(2, "Comirnaty", "mRNA", "2", "listed"),
+		row(3, "Covaxin", "inactivated", "", "pending"),
+		row(4, "", "mrna", "2", "listed"),
+		row(5, "Sputnik V", "adenovirus", "2", ""),
+	})
+
+	if len(platforms) != 2 {
+		t.Fatalf("readVaccinePlatforms() = %+v; want Comirnaty and Covaxin", platforms)
+	}
+	if got := platforms[0]; got.Product != "Comirnaty" || got.Platform != "mrna" || got.Doses == nil || *got.Doses != 2 || got.WHOEUL != "listed" {
+		t.Errorf("platforms[0] = %+v", got)
+	}
+	if got := platforms[1]; got.Doses != nil || got.WHOEUL != "" {
+		t.Errorf("platforms[1] = %+v; want no doses and no WHO EUL", got)
+	}
+
+	if report.rejected != 2 || report.coerced != 1 {
+		t.Errorf("rejected, coerced = %d, %d; want 2, 1", report.rejected, report.coerced)
+	}
+	rejects := readRejects(t, buf)
+	if len(rejects) != 3 || rejects[1].Field != colPlatformProduct || rejects[2].Field != colPlatform {
+		t.Errorf("rejects = %+v", rejects)
+	}
+}
+
+// The mapping is maintained by hand, so every row of it must load
+func TestReadVaccinePlatformsReferenceFile(t *testing.T) {
+	rejects, err := utils.NewRejectWriter(&strings.Builder{}, utils.RejectFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &ETLService{batchSize: 100, rejects: rejects}
+
+	var products []string
+	var report *fileReport
+	err = service.streamFile(context.Background(), filepath.Join("..", "data", "vaccine-platforms.csv"), vaccinePlatformColumns, func(r *fileReport, rows []sourceRow) batchWrite {
+		report = r
+		for _, platform := range readVaccinePlatforms(r, rows) {
+			products = append(products, platform.Product)
+		}
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			return repositories.BatchStats{}, nil
+		}
+	})
+	if err != nil {
+		t.Fatalf("streamFile() error = %v", err)
+	}
+	if len(products) == 0 || report.rejected != 0 || report.coerced != 0 {
+		t.Errorf("loaded %d products, rejected %d rows and coerced %d", len(products), report.rejected, report.coerced)
+	}
+}
+
+// Some country names in the shipped file hold the delimiter unquoted
+func TestVaccinationDataReferenceFileResolvesEveryCountry(t *testing.T) {
+	rejects, err := utils.NewRejectWriter(&strings.Builder{}, utils.RejectFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &ETLService{batchSize: 100, rejects: rejects}
+
+	names := make(map[string]string)
+	var report *fileReport
+	err = service.streamFile(context.Background(), filepath.Join("..", "data", "vaccination-data.csv"), vaccinationDataColumns, func(r *fileReport, rows []sourceRow) batchWrite {
+		report = r
+		for _, row := range rows {
+			row = r.rejoinSplit(row, colCountry, colISO3)
+			if country, ok := r.country(row, colISO3); ok {
+				names[country.Alpha3] = r.get(row, colCountry)
+			}
+		}
+		return func(ctx context.Context) (repositories.BatchStats, error) {
+			return repositories.BatchStats{}, nil
+		}
+	})
+	if err != nil {
+		t.Fatalf("streamFile() error = %v", err)
+	}
+	if report.rejected != 0 {
+		t.Errorf("rejected %d rows; want none", report.rejected)
+	}
+	if names["HKG"] != "China; Hong Kong SAR" || names["PSE"] != "occupied Palestinian territory; including east Jerusalem" {
+		t.Errorf("names = %q, %q", names["HKG"], names["PSE"])
+	}
+}
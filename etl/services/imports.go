@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/thalesmacedo1/covid-etl/repositories"
+	"github.com/thalesmacedo1/covid-shared/events"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// publishTimeout bounds publishing the import completed event, so an
+// unreachable Redis cannot hold up the end of the run.
+const publishTimeout = 5 * time.Second
+
+// importTracker collects, for each dataset, the countries and dates of the
+// rows the repositories inserted or updated, in the order the datasets first
+// changed. Rows a run found unchanged are left out, so their cached results
+// survive the run.
+type importTracker struct {
+	order    []events.Dataset
+	datasets map[events.Dataset]*trackedDataset
+}
+
+type trackedDataset struct {
+	countries map[string]struct{}
+	dates     *events.DateRange
+}
+
+func newImportTracker() *importTracker {
+	return &importTracker{datasets: make(map[events.Dataset]*trackedDataset)}
+}
+
+// recordDataset notes that dataset changed, even when no row of any country
+// did, such as a vaccine no country uses.
+func (t *importTracker) recordDataset(dataset events.Dataset) *trackedDataset {
+	tracked, ok := t.datasets[dataset]
+	if !ok {
+		tracked = &trackedDataset{countries: make(map[string]struct{})}
+		t.datasets[dataset] = tracked
+		t.order = append(t.order, dataset)
+	}
+	return tracked
+}
+
+// record notes a row of dataset for the country with the alpha-3 code, dated
+// date unless it is nil.
+func (t *importTracker) record(dataset events.Dataset, country string, date *time.Time) {
+	tracked := t.recordDataset(dataset)
+	tracked.countries[country] = struct{}{}
+	if date != nil {
+		if tracked.dates == nil {
+			tracked.dates = &events.DateRange{}
+		}
+		tracked.dates.Extend(*date)
+	}
+}
+
+// recordChanged notes the rows of dataset a write inserted or updated.
+func (t *importTracker) recordChanged(dataset events.Dataset, changed []repositories.ChangedRow) {
+	for _, row := range changed {
+		t.record(dataset, row.CountryCode, row.Date)
+	}
+}
+
+// empty reports whether nothing was recorded.
+func (t *importTracker) empty() bool {
+	return len(t.order) == 0
+}
+
+// event returns what was recorded as an import completed event.
+func (t *importTracker) event(completedAt time.Time) events.ImportCompleted {
+	event := events.ImportCompleted{
+		CompletedAt: completedAt,
+		Imports:     make([]events.DatasetImport, 0, len(t.order)),
+	}
+
+	for _, dataset := range t.order {
+		tracked := t.datasets[dataset]
+		countries := make([]string, 0, len(tracked.countries))
+		for country := range tracked.countries {
+			countries = append(countries, country)
+		}
+		sort.Strings(countries)
+
+		event.Imports = append(event.Imports, events.DatasetImport{
+			Dataset:   dataset,
+			Countries: countries,
+			Dates:     tracked.dates,
+		})
+	}
+	return event
+}
+
+// publishImportCompleted announces what the run changed on the imports
+// channel, so the API drops its cached results for those countries and dates.
+// Failing to publish only leaves those results cached until they expire, so it
+// is logged instead of failing a run whose data is already loaded.
+func (s *ETLService) publishImportCompleted(ctx context.Context) {
+	if s.events == nil {
+		log.Printf("REDIS_HOST is not set, not publishing the import completed event")
+		return
+	}
+	if s.imported.empty() {
+		log.Printf("The run changed no data, not publishing the import completed event")
+		return
+	}
+
+	payload, err := s.imported.event(time.Now().UTC()).Encode()
+	if err != nil {
+		log.Printf("Failed to publish the import completed event: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	receivers, err := s.events.Publish(ctx, events.ImportsChannel, payload).Result()
+	if err != nil {
+		log.Printf("Failed to publish the import completed event: %v", err)
+		return
+	}
+	log.Printf("Published the import completed event on %s to %d subscribers", events.ImportsChannel, receivers)
+}
+
+// newEventsClient connects to the Redis server events are published on.
+func newEventsClient(addr, password string, db int) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:         addr,
+		Password:     password,
+		DB:           db,
+		DialTimeout:  publishTimeout,
+		WriteTimeout: publishTimeout,
+	})
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thalesmacedo1/covid-etl/repositories"
+	"github.com/thalesmacedo1/covid-shared/events"
+)
+
+func day(value string) *time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return &date
+}
+
+func TestImportTrackerRecord(t *testing.T) {
+	tracker := newImportTracker()
+	if !tracker.empty() {
+		t.Fatalf("empty() = false for a new tracker")
+	}
+
+	tracker.record(events.DatasetVaccines, "CHL", nil)
+	tracker.record(events.DatasetCovid, "BRA", day("2024-02-10"))
+	tracker.record(events.DatasetCovid, "ARG", day("2024-01-05"))
+	tracker.record(events.DatasetCovid, "BRA", day("2024-03-01"))
+	tracker.record(events.DatasetVaccines, "ARG", nil)
+
+	if tracker.empty() {
+		t.Fatalf("empty() = true after recording rows")
+	}
+	if want := []events.Dataset{events.DatasetVaccines, events.DatasetCovid}; !reflect.DeepEqual(tracker.order, want) {
+		t.Errorf("order = %v; want %v", tracker.order, want)
+	}
+
+	covid := tracker.datasets[events.DatasetCovid]
+	if len(covid.countries) != 2 {
+		t.Errorf("covid countries = %v; want ARG and BRA", covid.countries)
+	}
+	if want := (events.DateRange{From: *day("2024-01-05"), To: *day("2024-03-01")}); covid.dates == nil || *covid.dates != want {
+		t.Errorf("covid dates = %+v; want %+v", covid.dates, want)
+	}
+	if vaccines := tracker.datasets[events.DatasetVaccines]; vaccines.dates != nil {
+		t.Errorf("vaccines dates = %+v; want nil", vaccines.dates)
+	}
+}
+
+func TestImportTrackerRecordChanged(t *testing.T) {
+	tracker := newImportTracker()
+	tracker.recordChanged(events.DatasetVaccinations, nil)
+	if !tracker.empty() {
+		t.Fatalf("recordChanged() recorded a dataset without changed rows")
+	}
+
+	tracker.recordChanged(events.DatasetVaccinations, []repositories.ChangedRow{
+		{CountryCode: "BRA", Date: day("2024-02-10")},
+		{CountryCode: "BRA", Date: day("2024-02-01")},
+	})
+	vaccinations := tracker.datasets[events.DatasetVaccinations]
+	if len(vaccinations.countries) != 1 || vaccinations.dates == nil || !vaccinations.dates.From.Equal(*day("2024-02-01")) {
+		t.Errorf("vaccinations = %+v, dates %+v", vaccinations.countries, vaccinations.dates)
+	}
+}
+
+func TestImportTrackerRecordDataset(t *testing.T) {
+	tracker := newImportTracker()
+	tracker.recordDataset(events.DatasetVaccines)
+	tracker.recordDataset(events.DatasetVaccines)
+
+	event := tracker.event(time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC))
+	if len(event.Imports) != 1 || event.Imports[0].Dataset != events.DatasetVaccines || len(event.Imports[0].Countries) != 0 || event.Imports[0].Dates != nil {
+		t.Errorf("event() = %+v; want the vaccines dataset without countries", event.Imports)
+	}
+}
+
+func TestImportTrackerEvent(t *testing.T) {
+	tracker := newImportTracker()
+	tracker.record(events.DatasetCovid, "BRA", day("2024-02-29"))
+	tracker.record(events.DatasetCovid, "ARG", day("2024-02-01"))
+	tracker.record(events.DatasetVaccines, "CHL", nil)
+
+	completedAt := time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)
+	payload, err := tracker.event(completedAt).Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	got, err := events.DecodeImportCompleted(payload)
+	if err != nil {
+		t.Fatalf("DecodeImportCompleted() error = %v", err)
+	}
+	want := events.ImportCompleted{
+		CompletedAt: completedAt,
+		Imports: []events.DatasetImport{
+			{Dataset: events.DatasetCovid, Countries: []string{"ARG", "BRA"}, Dates: &events.DateRange{From: *day("2024-02-01"), To: *day("2024-02-29")}},
+			{Dataset: events.DatasetVaccines, Countries: []string{"CHL"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("event() = %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thalesmacedo1/covid-etl/utils"
+	"github.com/thalesmacedo1/covid-shared/iso3166"
+	"github.com/thalesmacedo1/covid-shared/migrations"
+)
+
+// sourceRow is a data record of the file being loaded and the line it starts on.
+type sourceRow struct {
+	line   int
+	record []string
+}
+
+// fileReport reads the fields of one file's rows, writing every row it drops
+// or loads with a substituted value to the reject file.
+type fileReport struct {
+	file    string
+	cols    *utils.ColumnMap
+	rejects *utils.RejectWriter
+
+	rows     int
+	rejected int
+	coerced  int
+	// Lines of the last rejected and coerced rows, so each row counts once
+	lastRejected int
+	lastCoerced  int
+
+	// Country codes ISO 3166 does not know, with their row counts
+	unresolved map[string]int
+	// First error writing the reject file
+	err error
+}
+
+// checkBudget fails when more of the rows read were rejected than maxRatio
+// allows.
+func (r *fileReport) checkBudget(maxRatio float64) error {
+	if r.rows == 0 {
+		return nil
+	}
+	ratio := float64(r.rejected) / float64(r.rows)
+	if ratio > maxRatio {
+		return fmt.Errorf("%s: %d of %d rows rejected (%.2f%%), above the %.2f%% allowed by ETL_MAX_REJECT_RATIO",
+			r.file, r.rejected, r.rows, ratio*100, maxRatio*100)
+	}
+	return nil
+}
+
+func (r *fileReport) get(row sourceRow, column string) string {
+	return r.cols.Get(row.record, column)
+}
+
+// reject records that row is not loaded because of field.
+func (r *fileReport) reject(row sourceRow, field, reason string) {
+	if row.line != r.lastRejected {
+		r.rejected++
+		r.lastRejected = row.line
+	}
+	r.write(row, utils.RejectActionRejected, field, reason)
+}
+
+// coerce records that row is loaded with a substituted value for field.
+func (r *fileReport) coerce(row sourceRow, field, reason string) {
+	if row.line != r.lastCoerced {
+		r.coerced++
+		r.lastCoerced = row.line
+	}
+	r.write(row, utils.RejectActionCoerced, field, reason)
+}
+
+func (r *fileReport) write(row sourceRow, action utils.RejectAction, field, reason string) {
+	if r.err != nil {
+		return
+	}
+	r.err = r.rejects.Write(utils.Reject{
+		File:   r.file,
+		Line:   row.line,
+		Action: action,
+		Field:  field,
+		Reason: reason,
+		Record: row.record,
+	})
+}
+
+// int reads a numeric column. Blank values are not reported and load as nil.
+// Values that are not numbers also load as nil and fractions are truncated,
+// both recorded as coerced.
+func (r *fileReport) int(row sourceRow, column string) *int {
+	n, err := utils.TryParseInt(r.get(row, column))
+	switch {
+	case err == nil:
+	case errors.Is(err, utils.ErrEmptyValue):
+		return nil
+	case errors.Is(err, utils.ErrFractional):
+		r.coerce(row, column, err.Error())
+	default:
+		r.coerce(row, column, err.Error()+", loaded as null")
+		return nil
+	}
+	return &n
+}
+
+// float reads a decimal column such as the per-100 rates. Blank values load
+// as nil; values that are not numbers also load as nil, recorded as coerced.
+func (r *fileReport) float(row sourceRow, column string) *float64 {
+	f, err := utils.TryParseFloat(r.get(row, column))
+	switch {
+	case err == nil:
+		return &f
+	case !errors.Is(err, utils.ErrEmptyValue):
+		r.coerce(row, column, err.Error()+", loaded as null")
+	}
+	return nil
+}
+
+// date reads an optional date column. Blank values load as nil; invalid
+// dates also load as nil, recorded as coerced.
+func (r *fileReport) date(row sourceRow, column string) *time.Time {
+	date, err := utils.TryParseDate(r.get(row, column))
+	switch {
+	case err == nil:
+		return &date
+	case !errors.Is(err, utils.ErrEmptyValue):
+		r.coerce(row, column, err.Error()+", loaded as null")
+	}
+	return nil
+}
+
+// choice reads an optional column that holds one of allowed, ignoring case.
+// Blank values load as ""; values not allowed also load as "", recorded as
+// coerced.
+func (r *fileReport) choice(row sourceRow, column string, allowed []string) string {
+	value := strings.ToLower(strings.TrimSpace(r.get(row, column)))
+	if value == "" {
+		return ""
+	}
+	if !slices.Contains(allowed, value) {
+		r.coerce(row, column, fmt.Sprintf("unknown value %q, loaded as null", value))
+		return ""
+	}
+	return value
+}
+
+// requiredChoice reads a column that must hold one of allowed, ignoring
+// case, rejecting the row when it is blank or holds another value.
+func (r *fileReport) requiredChoice(row sourceRow, column string, allowed []string) (string, bool) {
+	value := strings.ToLower(strings.TrimSpace(r.get(row, column)))
+	if !slices.Contains(allowed, value) {
+		r.reject(row, column, fmt.Sprintf("unknown value %q, expected one of %v", value, allowed))
+		return "", false
+	}
+	return value, true
+}
+
+// requiredDate reads a date column that keys the row, rejecting the row when
+// it is blank or invalid.
+func (r *fileReport) requiredDate(row sourceRow, column string) (time.Time, bool) {
+	date, err := utils.TryParseDate(r.get(row, column))
+	if err != nil {
+		r.reject(row, column, err.Error())
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// country maps any ISO 3166 code form to its canonical country, rejecting
+// the row when the table does not know the code.
+func (r *fileReport) country(row sourceRow, column string) (iso3166.Country, bool) {
+	code := r.get(row, column)
+	country, ok := iso3166.Lookup(code)
+	if !ok {
+		r.unresolved[code]++
+		r.reject(row, column, fmt.Sprintf("unknown country code %q", code))
+	}
+	return country, ok
+}
+
+// rejoinSplit repairs a row whose column held the unquoted delimiter, which
+// splits its value in two and shifts every later field by one. The row is
+// repaired, and recorded as coerced, only when its key column does not hold a
+// known country code but does once the two parts are joined again.
+func (r *fileReport) rejoinSplit(row sourceRow, column, key string) sourceRow {
+	if _, ok := iso3166.Lookup(r.get(row, key)); ok {
+		return row
+	}
+
+	record, ok := r.cols.Rejoin(row.record, column, string(sourceDelimiter))
+	if !ok {
+		return row
+	}
+	if _, ok := iso3166.Lookup(r.cols.Get(record, key)); !ok {
+		return row
+	}
+
+	joined := sourceRow{line: row.line, record: record}
+	r.coerce(joined, column, fmt.Sprintf("value contained the delimiter, loaded as %q", r.get(joined, column)))
+	return joined
+}
+
+// requireProperties reports whether props holds every property migrations
+// requires for label, rejecting the row when it does not.
+func (r *fileReport) requireProperties(row sourceRow, label string, props map[string]interface{}) bool {
+	missing := migrations.MissingProperties(label, props)
+	if len(missing) > 0 {
+		r.reject(row, strings.Join(missing, ","), fmt.Sprintf("%s is missing required properties %v", label, missing))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/thalesmacedo1/covid-etl/utils"
+)
+
+var testColumns = []utils.Column{
+	{Name: "CODE", Required: true},
+	{Name: "VALUE"},
+}
+
+// newTestReport returns a report over the testColumns header, writing its
+// rejects as NDJSON to the returned buffer.
+func newTestReport(t *testing.T) (*fileReport, *bytes.Buffer) {
+	t.Helper()
+	return newColumnsReport(t, []string{"CODE", "VALUE"}, testColumns)
+}
+
+// newColumnsReport returns a report over header, read as columns.
+func newColumnsReport(t *testing.T, header []string, columns []utils.Column) (*fileReport, *bytes.Buffer) {
+	t.Helper()
+
+	cols, err := utils.MapColumns(header, columns)
+	if err != nil {
+		t.Fatalf("MapColumns() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	writer, err := utils.NewRejectWriter(&buf, utils.RejectFormatNDJSON)
+	if err != nil {
+		t.Fatalf("NewRejectWriter() error = %v", err)
+	}
+
+	return &fileReport{
+		file:       "test.csv",
+		cols:       cols,
+		rejects:    writer,
+		unresolved: make(map[string]int),
+	}, &buf
+}
+
+func readRejects(t *testing.T, buf *bytes.Buffer) []utils.Reject {
+	t.Helper()
+
+	var rejects []utils.Reject
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var reject utils.Reject
+		if err := json.Unmarshal(scanner.Bytes(), &reject); err != nil {
+			t.Fatalf("invalid reject %q: %v", scanner.Text(), err)
+		}
+		rejects = append(rejects, reject)
+	}
+	return rejects
+}
+
+func testRow(line int, code, value string) sourceRow {
+	return sourceRow{line: line, record: []string{code, value}}
+}
+
+func TestFileReportCountsEachRowOnce(t *testing.T) {
+	report, buf := newTestReport(t)
+
+	first := testRow(2, "BRA", "x")
+	report.reject(first, "CODE", "bad code")
+	report.reject(first, "VALUE", "bad value")
+	report.coerce(testRow(3, "ARG", "1.5"), "VALUE", "truncated")
+	report.coerce(testRow(4, "CHL", "2.5"), "VALUE", "truncated")
+
+	if report.rejected != 1 || report.coerced != 2 {
+		t.Errorf("rejected, coerced = %d, %d; want 1, 2", report.rejected, report.coerced)
+	}
+
+	rejects := readRejects(t, buf)
+	if len(rejects) != 4 {
+		t.Fatalf("wrote %d rejects; want 4", len(rejects))
+	}
+	if rejects[1].Line != 2 || rejects[1].Action != utils.RejectActionRejected || rejects[1].Field != "VALUE" {
+		t.Errorf("second reject = %+v", rejects[1])
+	}
+}
+
+func TestFileReportInt(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		want        *int
+		wantCoerced int
+	}{
+		{name: "Number", value: "42", want: intPtr(42)},
+		{name: "Blank", value: " "},
+		{name: "Fraction", value: "42.7", want: intPtr(42), wantCoerced: 1},
+		{name: "Not a number", value: "n/a", wantCoerced: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, _ := newTestReport(t)
+
+			got := report.int(testRow(2, "BRA", tt.value), "VALUE")
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("int(%q) = %v; want %v", tt.value, got, tt.want)
+			}
+			if report.coerced != tt.wantCoerced || report.rejected != 0 {
+				t.Errorf("coerced, rejected = %d, %d; want %d, 0", report.coerced, report.rejected, tt.wantCoerced)
+			}
+		})
+	}
+}
+
+func TestFileReportFloatAndDate(t *testing.T) {
+	report, _ := newTestReport(t)
+
+	if got := report.float(testRow(2, "BRA", "79.5"), "VALUE"); got == nil || *got != 79.5 {
+		t.Errorf("float(79.5) = %v", got)
+	}
+	if got := report.float(testRow(3, "BRA", "abc"), "VALUE"); got != nil {
+		t.Errorf("float(abc) = %v; want nil", *got)
+	}
+	if got := report.date(testRow(4, "BRA", "05/01/2020"), "VALUE"); got == nil || got.Format("2006-01-02") != "2020-01-05" {
+		t.Errorf("date(05/01/2020) = %v", got)
+	}
+	if got := report.date(testRow(5, "BRA", "2020-13-45"), "VALUE"); got != nil {
+		t.Errorf("date(2020-13-45) = %v; want nil", *got)
+	}
+	if got := report.date(testRow(6, "BRA", ""), "VALUE"); got != nil {
+		t.Errorf("date() = %v; want nil", *got)
+	}
+
+	if report.coerced != 2 || report.rejected != 0 {
+		t.Errorf("coerced, rejected = %d, %d; want 2, 0", report.coerced, report.rejected)
+	}
+}
+
+func TestFileReportRequiredFields(t *testing.T) {
+	report, buf := newTestReport(t)
+
+	if _, ok := report.requiredDate(testRow(2, "BRA", ""), "VALUE"); ok {
+		t.Errorf("requiredDate() accepted a blank date")
+	}
+	if country, ok := report.country(testRow(3, "BR", ""), "CODE"); !ok || country.Alpha3 != "BRA" {
+		t.Errorf("country(BR) = %+v, %t; want BRA", country, ok)
+	}
+	if _, ok := report.country(testRow(4, "XXA", ""), "CODE"); ok {
+		t.Errorf("country(XXA) accepted an unknown code")
+	}
+	if report.requireProperties(testRow(5, "BRA", ""), "Country", map[string]interface{}{"code": "BRA", "name": " "}) {
+		t.Errorf("requireProperties() accepted a blank name")
+	}
+
+	if report.rejected != 3 || report.unresolved["XXA"] != 1 {
+		t.Errorf("rejected = %d, unresolved = %v; want 3 and XXA once", report.rejected, report.unresolved)
+	}
+	if rejects := readRejects(t, buf); len(rejects) != 3 || rejects[2].Field != "name" {
+		t.Errorf("rejects = %+v", rejects)
+	}
+}
+
+func TestFileReportRejoinSplit(t *testing.T) {
+	report, buf := newColumnsReport(t, []string{"NAME", "CODE", "VALUE"}, []utils.Column{{Name: "NAME"}, {Name: "CODE"}, {Name: "VALUE"}})
+	row := func(line int, values ...string) sourceRow {
+		return sourceRow{line: line, record: values}
+	}
+
+	split := report.rejoinSplit(row(2, "China", " Hong Kong SAR", "HKG", "1"), "NAME", "CODE")
+	if report.get(split, "NAME") != "China; Hong Kong SAR" || report.get(split, "CODE") != "HKG" || report.get(split, "VALUE") != "1" {
+		t.Errorf("rejoinSplit() = %q", split.record)
+	}
+
+	whole := row(3, "Brazil", "BRA", "2")
+	if got := report.rejoinSplit(whole, "NAME", "CODE"); len(got.record) != 3 || report.get(got, "NAME") != "Brazil" {
+		t.Errorf("rejoinSplit() changed a row with a known code: %q", got.record)
+	}
+	unknown := row(4, "Atlantis", "XXA", "3")
+	if got := report.rejoinSplit(unknown, "NAME", "CODE"); report.get(got, "CODE") != "XXA" {
+		t.Errorf("rejoinSplit() changed a row that stays unknown: %q", got.record)
+	}
+
+	if report.coerced != 1 || report.rejected != 0 {
+		t.Errorf("coerced, rejected = %d, %d; want 1, 0", report.coerced, report.rejected)
+	}
+	if rejects := readRejects(t, buf); len(rejects) != 1 || rejects[0].Line != 2 || rejects[0].Field != "NAME" {
+		t.Errorf("rejects = %+v", rejects)
+	}
+}
+
+func TestFileReportChoice(t *testing.T) {
+	allowed := []string{"listed", "not_listed"}
+	tests := []struct {
+		name         string
+		value        string
+		want         string
+		wantCoerced  int
+		wantRequired bool
+	}{
+		{name: "Allowed", value: "listed", want: "listed", wantRequired: true},
+		{name: "Any case", value: " Not_Listed ", want: "not_listed", wantRequired: true},
+		{name: "Blank", value: ""},
+		{name: "Not allowed", value: "pending", wantCoerced: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, _ := newTestReport(t)
+
+			if got := report.choice(testRow(2, "BRA", tt.value), "VALUE", allowed); got != tt.want {
+				t.Errorf("choice(%q) = %q; want %q", tt.value, got, tt.want)
+			}
+			if report.coerced != tt.wantCoerced || report.rejected != 0 {
+				t.Errorf("choice(%q) coerced, rejected = %d, %d; want %d, 0", tt.value, report.coerced, report.rejected, tt.wantCoerced)
+			}
+
+			got, ok := report.requiredChoice(testRow(3, "BRA", tt.value), "VALUE", allowed)
+			if ok != tt.wantRequired || got != tt.want {
+				t.Errorf("requiredChoice(%q) = %q, %t; want %q, %t", tt.value, got, ok, tt.want, tt.wantRequired)
+			}
+			if wantRejected := map[bool]int{true: 0, false: 1}[tt.wantRequired]; report.rejected != wantRejected {
+				t.Errorf("requiredChoice(%q) rejected = %d; want %d", tt.value, report.rejected, wantRejected)
+			}
+		})
+	}
+}
+
+func TestFileReportCheckBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     int
+		rejected int
+		maxRatio float64
+		wantErr  bool
+	}{
+		{name: "No rows", maxRatio: 0},
+		{name: "Under budget", rows: 100, rejected: 4, maxRatio: 0.05},
+		{name: "At budget", rows: 100, rejected: 5, maxRatio: 0.05},
+		{name: "Over budget", rows: 100, rejected: 6, maxRatio: 0.05, wantErr: true},
+		{name: "No rejects allowed", rows: 100, rejected: 1, maxRatio: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &fileReport{file: "test.csv", rows: tt.rows, rejected: tt.rejected}
+			if err := report.checkBudget(tt.maxRatio); (err != nil) != tt.wantErr {
+				t.Errorf("checkBudget(%v) error = %v; wantErr %t", tt.maxRatio, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
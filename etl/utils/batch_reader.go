@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+// CSVBatchReader streams a delimited file and hands its records back in
+// groups of at most batchSize, so the whole file is never held in memory.
+type CSVBatchReader struct {
+	reader    *csv.Reader
+	batchSize int
+	lines     []int
+}
+
+func NewCSVBatchReader(r io.Reader, comma rune, batchSize int) *CSVBatchReader {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	// Some source rows carry an extra delimiter; let callers decide what to do with them
+	reader.FieldsPerRecord = -1
+
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+
+	return &CSVBatchReader{
+		reader:    reader,
+		batchSize: batchSize,
+	}
+}
+
+// Header reads the next record, which is expected to be the header row.
+func (b *CSVBatchReader) Header() ([]string, error) {
+	return b.reader.Read()
+}
+
+// Next returns the next batch of records. It returns io.EOF once the input is
+// exhausted; a short final batch is returned together with a nil error.
+func (b *CSVBatchReader) Next() ([][]string, error) {
+	batch := make([][]string, 0, b.batchSize)
+	b.lines = make([]int, 0, b.batchSize)
+
+	for len(batch) < b.batchSize {
+		record, err := b.reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := b.reader.FieldPos(0)
+		batch = append(batch, record)
+		b.lines = append(b.lines, line)
+	}
+
+	if len(batch) == 0 {
+		return nil, io.EOF
+	}
+
+	return batch, nil
+}
+
+// Lines returns the line numbers in the input where the records of the last
+// batch returned by Next start.
+func (b *CSVBatchReader) Lines() []int {
+	return b.lines
+}
+
+// Field returns record[i], or an empty string when the record is too short.
+func Field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
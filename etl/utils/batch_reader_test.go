@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVBatchReader(t *testing.T) {
+	input := "code;name\nAF;Afghanistan\nAL;Albania\nDZ;Algeria\nAD;Andorra;\nAO;Angola\n"
+
+	reader := NewCSVBatchReader(strings.NewReader(input), ';', 2)
+
+	header, err := reader.Header()
+	if err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+	if strings.Join(header, ",") != "code,name" {
+		t.Errorf("Header() = %v; want [code name]", header)
+	}
+
+	var sizes, lines []int
+	var codes []string
+	for {
+		batch, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		sizes = append(sizes, len(batch))
+		lines = append(lines, reader.Lines()...)
+		for _, record := range batch {
+			codes = append(codes, record[0])
+		}
+	}
+
+	if got := len(sizes); got != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Errorf("batch sizes = %v; want [2 2 1]", sizes)
+	}
+	if strings.Join(codes, ",") != "AF,AL,DZ,AD,AO" {
+		t.Errorf("codes = %v; want [AF AL DZ AD AO]", codes)
+	}
+	if len(lines) != 5 || lines[0] != 2 || lines[4] != 6 {
+		t.Errorf("lines = %v; want [2 3 4 5 6]", lines)
+	}
+}
+
+func TestCSVBatchReaderEmpty(t *testing.T) {
+	reader := NewCSVBatchReader(strings.NewReader("code;name\n"), ';', 10)
+
+	if _, err := reader.Header(); err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+	if _, err := reader.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() error = %v; want io.EOF", err)
+	}
+}
+
+func TestField(t *testing.T) {
+	record := []string{"a", "b"}
+
+	if got := Field(record, 1); got != "b" {
+		t.Errorf("Field(record, 1) = %q; want %q", got, "b")
+	}
+	if got := Field(record, 5); got != "" {
+		t.Errorf("Field(record, 5) = %q; want empty string", got)
+	}
+}
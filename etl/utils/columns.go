@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column declares a column a dataset reads, by its header name. Required
+// columns must be present in the file; optional ones read as empty when the
+// file does not have them.
+type Column struct {
+	Name     string
+	Required bool
+}
+
+// ColumnMap holds the positions of a dataset's columns in one file, as
+// resolved from that file's header row.
+type ColumnMap struct {
+	positions map[string]int
+	declared  map[string]bool
+	width     int
+
+	// Missing lists the optional columns the file does not have
+	Missing []string
+	// Extra lists the columns the file has but the dataset does not declare
+	Extra []string
+}
+
+// MapColumns matches header against the declared columns. Header names are
+// compared ignoring case and surrounding spaces. It fails when a required
+// column is absent or a declared column appears twice.
+func MapColumns(header []string, columns []Column) (*ColumnMap, error) {
+	m := &ColumnMap{
+		positions: make(map[string]int, len(columns)),
+		declared:  make(map[string]bool, len(columns)),
+		width:     len(header),
+	}
+
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		name = normalizeHeader(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("column %s appears more than once in the header", name)
+		}
+		byName[name] = i
+	}
+
+	var missingRequired []string
+	for _, column := range columns {
+		name := normalizeHeader(column.Name)
+		m.declared[name] = true
+
+		i, ok := byName[name]
+		switch {
+		case ok:
+			m.positions[name] = i
+		case column.Required:
+			missingRequired = append(missingRequired, column.Name)
+		default:
+			m.Missing = append(m.Missing, column.Name)
+		}
+	}
+
+	if len(missingRequired) > 0 {
+		return nil, fmt.Errorf("missing required columns %s", strings.Join(missingRequired, ", "))
+	}
+
+	for i, name := range header {
+		if normalized := normalizeHeader(name); normalized != "" && !m.declared[normalized] {
+			m.Extra = append(m.Extra, strings.TrimSpace(header[i]))
+		}
+	}
+
+	return m, nil
+}
+
+// Get returns the value of the named column in record, or an empty string
+// when the column is optional and absent. Asking for a column the dataset
+// never declared is a programming error and panics.
+func (m *ColumnMap) Get(record []string, name string) string {
+	name = normalizeHeader(name)
+	if !m.declared[name] {
+		panic(fmt.Sprintf("column %s was not declared", name))
+	}
+
+	i, ok := m.positions[name]
+	if !ok {
+		return ""
+	}
+	return Field(record, i)
+}
+
+// Fits reports whether record has no more fields than the header, so its
+// values line up with the mapped columns.
+func (m *ColumnMap) Fits(record []string) bool {
+	return len(record) <= m.width
+}
+
+// Rejoin returns record with the value of the named column joined to the
+// field after it by sep, for rows whose value held an unquoted delimiter and
+// was split in two. It reports false when the column is absent or is the
+// last field of record.
+func (m *ColumnMap) Rejoin(record []string, name, sep string) ([]string, bool) {
+	i, ok := m.positions[normalizeHeader(name)]
+	if !ok || i+1 >= len(record) {
+		return nil, false
+	}
+
+	joined := make([]string, 0, len(record)-1)
+	joined = append(joined, record[:i]...)
+	joined = append(joined, record[i]+sep+record[i+1])
+	return append(joined, record[i+2:]...), true
+}
+
+func normalizeHeader(name string) string {
+	// Strip the UTF-8 byte order mark some exports put before the first column
+	name = strings.TrimPrefix(name, "\ufeff")
+	return strings.ToUpper(strings.TrimSpace(name))
+}
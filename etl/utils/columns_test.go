@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapColumns(t *testing.T) {
+	header := []string{"\ufeffDate_reported", "Country_code", "WHO_region", "New_cases", "Cumulative_cases", ""}
+	columns := []Column{
+		{Name: "COUNTRY_CODE", Required: true},
+		{Name: "DATE_REPORTED", Required: true},
+		{Name: "CUMULATIVE_CASES"},
+		{Name: "NEW_DEATHS"},
+	}
+
+	m, err := MapColumns(header, columns)
+	if err != nil {
+		t.Fatalf("MapColumns() error = %v", err)
+	}
+
+	record := []string{"05/01/2020", "AF", "EMRO", "3", "10", ""}
+	if got := m.Get(record, "COUNTRY_CODE"); got != "AF" {
+		t.Errorf("Get(COUNTRY_CODE) = %q; want %q", got, "AF")
+	}
+	if got := m.Get(record, "Date_Reported"); got != "05/01/2020" {
+		t.Errorf("Get(Date_Reported) = %q; want %q", got, "05/01/2020")
+	}
+	if got := m.Get(record, "CUMULATIVE_CASES"); got != "10" {
+		t.Errorf("Get(CUMULATIVE_CASES) = %q; want %q", got, "10")
+	}
+	if got := m.Get(record, "NEW_DEATHS"); got != "" {
+		t.Errorf("Get(NEW_DEATHS) = %q; want empty for an absent optional column", got)
+	}
+
+	if strings.Join(m.Missing, ",") != "NEW_DEATHS" {
+		t.Errorf("Missing = %v; want [NEW_DEATHS]", m.Missing)
+	}
+	if strings.Join(m.Extra, ",") != "WHO_region,New_cases" {
+		t.Errorf("Extra = %v; want [WHO_region New_cases]", m.Extra)
+	}
+}
+
+func TestMapColumnsReordered(t *testing.T) {
+	columns := []Column{{Name: "A", Required: true}, {Name: "B", Required: true}}
+
+	m, err := MapColumns([]string{"B", "A"}, columns)
+	if err != nil {
+		t.Fatalf("MapColumns() error = %v", err)
+	}
+
+	record := []string{"b", "a"}
+	if m.Get(record, "A") != "a" || m.Get(record, "B") != "b" {
+		t.Errorf("Get() did not follow the header order")
+	}
+}
+
+func TestMapColumnsErrors(t *testing.T) {
+	columns := []Column{{Name: "A", Required: true}, {Name: "B", Required: true}, {Name: "C"}}
+
+	if _, err := MapColumns([]string{"A", "C"}, columns); err == nil || !strings.Contains(err.Error(), "B") {
+		t.Errorf("MapColumns() error = %v; want missing required column B", err)
+	}
+
+	if _, err := MapColumns([]string{"A", "B", "a"}, columns); err == nil {
+		t.Errorf("MapColumns() expected an error for a duplicated column")
+	}
+}
+
+func TestColumnMapFits(t *testing.T) {
+	m, err := MapColumns([]string{"A", "B"}, []Column{{Name: "A", Required: true}})
+	if err != nil {
+		t.Fatalf("MapColumns() error = %v", err)
+	}
+
+	if !m.Fits([]string{"1"}) || !m.Fits([]string{"1", "2"}) {
+		t.Errorf("Fits() = false for a record no wider than the header")
+	}
+	if m.Fits([]string{"1", "2", "3"}) {
+		t.Errorf("Fits() = true for a record wider than the header")
+	}
+}
+
+func TestColumnMapGetUndeclaredPanics(t *testing.T) {
+	m, _ := MapColumns([]string{"A"}, []Column{{Name: "A", Required: true}})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Get() of an undeclared column did not panic")
+		}
+	}()
+	m.Get([]string{"1"}, "B")
+}
+
+func TestColumnMapRejoin(t *testing.T) {
+	m, err := MapColumns([]string{"NAME", "CODE", "VALUE"}, []Column{{Name: "NAME", Required: true}, {Name: "CODE", Required: true}, {Name: "VALUE"}})
+	if err != nil {
+		t.Fatalf("MapColumns() error = %v", err)
+	}
+
+	got, ok := m.Rejoin([]string{"China", " Hong Kong SAR", "HKG", "1"}, "name", ";")
+	if !ok || strings.Join(got, "|") != "China; Hong Kong SAR|HKG|1" {
+		t.Errorf("Rejoin() = %q, %t", got, ok)
+	}
+	if _, ok := m.Rejoin([]string{"China"}, "NAME", ";"); ok {
+		t.Errorf("Rejoin() joined the last field of a record")
+	}
+	if _, ok := m.Rejoin([]string{"China", "CHN"}, "REGION", ";"); ok {
+		t.Errorf("Rejoin() joined a column the file does not have")
+	}
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,38 +11,68 @@ import (
 )
 
 var (
-	datePattern = regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`) // Matches YYYY-MM-DD
+	datePattern = regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`) // Matches DD/MM/YYYY
 )
 
-func ParseDate(value string) time.Time {
+var (
+	// ErrEmptyValue is returned for blank values
+	ErrEmptyValue = errors.New("empty value")
+	// ErrFractional is returned together with the truncated value when a
+	// number has a fractional part
+	ErrFractional = errors.New("value is not a whole number")
+)
+
+// TryParseDate parses a DD/MM/YYYY date, reporting why value is not one.
+func TryParseDate(value string) (time.Time, error) {
 	value = strings.TrimSpace(value)
 
-	if value == "" || !datePattern.MatchString(value) {
-		return time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if value == "" {
+		return time.Time{}, ErrEmptyValue
+	}
+	if !datePattern.MatchString(value) {
+		return time.Time{}, fmt.Errorf("%q is not a DD/MM/YYYY date", value)
 	}
 
-	layout := "02/01/2006"
-
-	date, err := time.Parse(layout, value)
+	date, err := time.Parse("02/01/2006", value)
 	if err != nil {
-		log.Printf("Erro ao converter a data '%s': %v", value, err)
-		return time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+		return time.Time{}, fmt.Errorf("%q is not a valid date", value)
 	}
 
-	return date
+	return date, nil
 }
 
-func ParseInt(value string) int {
+// TryParseInt parses an integer, also accepting scientific notation. A value
+// with a fractional part is truncated and returned along with ErrFractional.
+func TryParseInt(value string) (int, error) {
 	value = strings.TrimSpace(value)
 	if value == "" {
-		return 0
+		return 0, ErrEmptyValue
 	}
 
 	// Parse as float to handle scientific notation
-	f, err := strconv.ParseFloat(value, 64)
+	f, err := TryParseFloat(value)
 	if err != nil {
-		log.Printf("Error parsing number '%s': %v", value, err)
-		return 0
+		return 0, err
+	}
+
+	n := int(f)
+	if float64(n) != f {
+		return n, fmt.Errorf("%q truncated to %d: %w", value, n, ErrFractional)
+	}
+	return n, nil
+}
+
+// TryParseFloat parses a decimal number, also accepting scientific notation.
+// NaN and infinities are rejected, since they cannot be encoded as JSON.
+func TryParseFloat(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, ErrEmptyValue
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%q is not a number", value)
 	}
-	return int(f)
+	return f, nil
 }
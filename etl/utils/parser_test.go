@@ -1,129 +1,94 @@
 package utils
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
 
-func TestParseDate(t *testing.T) {
+func TestTryParseDate(t *testing.T) {
+	valid := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "01/02/2023", want: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{input: " 15/08/1947 ", want: time.Date(1947, 8, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "29/02/2020", want: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range valid {
+		date, err := TryParseDate(tt.input)
+		if err != nil || !date.Equal(tt.want) {
+			t.Errorf("TryParseDate(%q) = %v, %v; want %v", tt.input, date, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "  "} {
+		if _, err := TryParseDate(input); !errors.Is(err, ErrEmptyValue) {
+			t.Errorf("TryParseDate(%q) error = %v; want ErrEmptyValue", input, err)
+		}
+	}
+
+	for _, input := range []string{"12/2023", "31/02/2023", "31/04/2021", "15/13/2021", "00/12/2021", "2023-02-01"} {
+		if _, err := TryParseDate(input); err == nil || errors.Is(err, ErrEmptyValue) {
+			t.Errorf("TryParseDate(%q) error = %v; want a parse error", input, err)
+		}
+	}
+}
+
+func TestTryParseInt(t *testing.T) {
 	tests := []struct {
-		input    string
-		expected time.Time
+		input string
+		want  int
+		err   error
 	}{
-		{
-			input:    "01/02/2023",
-			expected: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    " 15/08/1947 ",
-			expected: time.Date(1947, 8, 15, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "12/2023",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "31/02/2023",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "   ",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "29/02/2020",
-			expected: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "31/04/2021",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "15/13/2021",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
-		{
-			input:    "00/12/2021",
-			expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
-		},
+		{input: "123", want: 123},
+		{input: "1.68E+07", want: 16800000},
+		{input: "9.99E+08", want: 999000000},
+		{input: " 42 ", want: 42},
+		{input: "-42", want: -42},
+		{input: "0", want: 0},
+		{input: "", err: ErrEmptyValue},
+		{input: "789.56", want: 789, err: ErrFractional},
+		{input: "1.23E-4", want: 0, err: ErrFractional},
+	}
+
+	for _, tt := range tests {
+		got, err := TryParseInt(tt.input)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("TryParseInt(%q) = %d, %v; want %d, %v", tt.input, got, err, tt.want, tt.err)
+		}
 	}
 
-	for _, test := range tests {
-		result := ParseDate(test.input)
-		if !result.Equal(test.expected) {
-			t.Errorf("ParseDate(%q) = %v; esperado %v", test.input, result, test.expected)
+	for _, input := range []string{"abcd", "NaN", "Inf"} {
+		if _, err := TryParseInt(input); err == nil || errors.Is(err, ErrFractional) {
+			t.Errorf("TryParseInt(%q) error = %v; want a parse error", input, err)
 		}
 	}
 }
 
-func TestParseInt(t *testing.T) {
+func TestTryParseFloat(t *testing.T) {
 	tests := []struct {
-		name           string
-		input          string
-		expectedOutput int
+		input string
+		want  float64
+		err   error
 	}{
-		{
-			name:           "Valid integer",
-			input:          "123",
-			expectedOutput: 123,
-		},
-		{
-			name:           "Scientific notation",
-			input:          "1.68E+07",
-			expectedOutput: 16800000,
-		},
-		{
-			name:           "Integer with spaces",
-			input:          "   456   ",
-			expectedOutput: 456,
-		},
-		{
-			name:           "Empty string",
-			input:          "",
-			expectedOutput: 0,
-		},
-		{
-			name:           "Non-numeric string",
-			input:          "abcd",
-			expectedOutput: 0,
-		},
-		{
-			name:           "Scientific notation with negative exponent",
-			input:          "1.23E-4",
-			expectedOutput: 0, // Converts to 0 when cast to int
-		},
-		{
-			name:           "Floating point number",
-			input:          "789.56",
-			expectedOutput: 789, // Truncated to 789
-		},
-		{
-			name:           "Negative integer",
-			input:          "-42",
-			expectedOutput: -42,
-		},
-		{
-			name:           "Zero",
-			input:          "0",
-			expectedOutput: 0,
-		},
-		{
-			name:           "Large number",
-			input:          "9.99E+08",
-			expectedOutput: 999000000,
-		},
+		{input: "183.47", want: 183.47},
+		{input: " 79 ", want: 79},
+		{input: "1.5E+02", want: 150},
+		{input: "", err: ErrEmptyValue},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := ParseInt(tt.input)
-			if result != tt.expectedOutput {
-				t.Errorf("ParseInt(%q) = %d; want %d", tt.input, result, tt.expectedOutput)
-			}
-		})
+		got, err := TryParseFloat(tt.input)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("TryParseFloat(%q) = %v, %v; want %v, %v", tt.input, got, err, tt.want, tt.err)
+		}
+	}
+
+	for _, input := range []string{"n/a", "NaN", "nan", "Inf", "-Inf", "+Infinity", "1e400"} {
+		if _, err := TryParseFloat(input); err == nil || errors.Is(err, ErrEmptyValue) {
+			t.Errorf("TryParseFloat(%q) error = %v; want a parse error", input, err)
+		}
 	}
 }
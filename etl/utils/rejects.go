@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RejectFormat is the encoding of a reject file.
+type RejectFormat string
+
+const (
+	RejectFormatCSV    RejectFormat = "csv"
+	RejectFormatNDJSON RejectFormat = "ndjson"
+)
+
+// ParseRejectFormat validates a reject file format name.
+func ParseRejectFormat(value string) (RejectFormat, error) {
+	switch format := RejectFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case RejectFormatCSV, RejectFormatNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown reject file format %q, use csv or ndjson", value)
+	}
+}
+
+// RejectAction tells whether the source row was dropped or loaded with a
+// substituted value.
+type RejectAction string
+
+const (
+	RejectActionRejected RejectAction = "rejected"
+	RejectActionCoerced  RejectAction = "coerced"
+)
+
+// Reject is one problem found in a source row.
+type Reject struct {
+	File   string       `json:"file"`
+	Line   int          `json:"line"`
+	Action RejectAction `json:"action"`
+	Field  string       `json:"field,omitempty"`
+	Reason string       `json:"reason"`
+	Record []string     `json:"record"`
+}
+
+var rejectHeader = []string{"file", "line", "action", "field", "reason", "record"}
+
+// RejectWriter writes rejects as CSV, one row per reject with the original
+// record re-joined by ';', or as newline-delimited JSON.
+type RejectWriter struct {
+	format  RejectFormat
+	csv     *csv.Writer
+	encoder *json.Encoder
+}
+
+func NewRejectWriter(w io.Writer, format RejectFormat) (*RejectWriter, error) {
+	switch format {
+	case RejectFormatCSV:
+		writer := csv.NewWriter(w)
+		writer.Comma = ';'
+		if err := writer.Write(rejectHeader); err != nil {
+			return nil, err
+		}
+		return &RejectWriter{format: format, csv: writer}, nil
+	case RejectFormatNDJSON:
+		return &RejectWriter{format: format, encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown reject file format %q", format)
+	}
+}
+
+func (w *RejectWriter) Write(reject Reject) error {
+	if w.format == RejectFormatNDJSON {
+		return w.encoder.Encode(reject)
+	}
+
+	return w.csv.Write([]string{
+		reject.File,
+		strconv.Itoa(reject.Line),
+		string(reject.Action),
+		reject.Field,
+		reject.Reason,
+		strings.Join(reject.Record, ";"),
+	})
+}
+
+// Flush writes any buffered rejects to the underlying writer.
+func (w *RejectWriter) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
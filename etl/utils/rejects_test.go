@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testReject = Reject{
+	File:   "vaccination-data.csv",
+	Line:   12,
+	Action: RejectActionRejected,
+	Field:  "ISO3",
+	Reason: `unknown country code "XXA"`,
+	Record: []string{"Somewhere", "XXA", "OTHER"},
+}
+
+func TestRejectWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewRejectWriter(&buf, RejectFormatCSV)
+	if err != nil {
+		t.Fatalf("NewRejectWriter() error = %v", err)
+	}
+	if err := writer.Write(testReject); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "file;line;action;field;reason;record\n" +
+		`vaccination-data.csv;12;rejected;ISO3;"unknown country code ""XXA""";"Somewhere;XXA;OTHER"` + "\n"
+	if buf.String() != want {
+		t.Errorf("CSV output = %q; want %q", buf.String(), want)
+	}
+}
+
+func TestRejectWriterNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer, err := NewRejectWriter(&buf, RejectFormatNDJSON)
+	if err != nil {
+		t.Fatalf("NewRejectWriter() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := writer.Write(testReject); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines; want 2", len(lines))
+	}
+
+	var decoded Reject
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.Line != 12 || decoded.Field != "ISO3" || strings.Join(decoded.Record, ";") != "Somewhere;XXA;OTHER" {
+		t.Errorf("decoded reject = %+v; want %+v", decoded, testReject)
+	}
+}
+
+func TestParseRejectFormat(t *testing.T) {
+	if format, err := ParseRejectFormat(" NDJSON "); err != nil || format != RejectFormatNDJSON {
+		t.Errorf("ParseRejectFormat(\" NDJSON \") = %q, %v; want ndjson", format, err)
+	}
+	if _, err := ParseRejectFormat("xml"); err == nil {
+		t.Error("ParseRejectFormat(\"xml\") error = nil; want an error")
+	}
+}
@@ -0,0 +1,87 @@
+// Package events holds the messages the ETL and the API exchange through
+// Redis. Both sides encode and decode them here, so the format cannot drift
+// between the publisher and its subscribers.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ImportsChannel is the Redis Pub/Sub channel ImportCompleted events are
+// published on.
+const ImportsChannel = "covid:imports"
+
+// Dataset names one of the source files the ETL imports.
+type Dataset string
+
+const (
+	// DatasetCovid is WHO-COVID-19-global-data.csv, the daily cases and deaths
+	DatasetCovid Dataset = "covid"
+	// DatasetVaccinations is vaccination-data.csv, the countries and their vaccination stats
+	DatasetVaccinations Dataset = "vaccinations"
+	// DatasetVaccines is vaccination-metadata.csv, the vaccines each country uses
+	DatasetVaccines Dataset = "vaccines"
+)
+
+// ImportCompleted announces a finished ETL run and what it wrote, so the
+// cached results of exactly those countries and dates can be dropped.
+type ImportCompleted struct {
+	CompletedAt time.Time       `json:"completedAt"`
+	Imports     []DatasetImport `json:"imports"`
+}
+
+// DatasetImport lists the countries, by alpha-3 code, a dataset wrote rows
+// for and the dates of those rows. Dates is nil for datasets whose rows are
+// not dated.
+type DatasetImport struct {
+	Dataset   Dataset    `json:"dataset"`
+	Countries []string   `json:"countries"`
+	Dates     *DateRange `json:"dates,omitempty"`
+}
+
+// DateRange is a closed range of days.
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Extend widens the range to include date.
+func (r *DateRange) Extend(date time.Time) {
+	if r.From.IsZero() || date.Before(r.From) {
+		r.From = date
+	}
+	if r.To.IsZero() || date.After(r.To) {
+		r.To = date
+	}
+}
+
+// Encode returns the JSON payload of the event.
+func (e ImportCompleted) Encode() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode import completed event: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeImportCompleted parses a payload written by Encode.
+func DecodeImportCompleted(data []byte) (ImportCompleted, error) {
+	var event ImportCompleted
+	if err := json.Unmarshal(data, &event); err != nil {
+		return ImportCompleted{}, fmt.Errorf("failed to decode import completed event: %w", err)
+	}
+
+	for _, imported := range event.Imports {
+		switch imported.Dataset {
+		case DatasetCovid, DatasetVaccinations, DatasetVaccines:
+		default:
+			return ImportCompleted{}, fmt.Errorf("import completed event has unknown dataset %q", imported.Dataset)
+		}
+		if imported.Dates != nil && imported.Dates.To.Before(imported.Dates.From) {
+			return ImportCompleted{}, fmt.Errorf("import completed event has %s dates ending before they start", imported.Dataset)
+		}
+	}
+	return event, nil
+}
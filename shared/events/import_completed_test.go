@@ -0,0 +1,68 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func day(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func TestImportCompletedRoundTrip(t *testing.T) {
+	event := ImportCompleted{
+		CompletedAt: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Imports: []DatasetImport{
+			{Dataset: DatasetCovid, Countries: []string{"ARG", "BRA"}, Dates: &DateRange{From: day("2024-02-01"), To: day("2024-02-29")}},
+			{Dataset: DatasetVaccines, Countries: []string{"BRA"}},
+		},
+	}
+
+	data, err := event.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	decoded, err := DecodeImportCompleted(data)
+	if err != nil {
+		t.Fatalf("DecodeImportCompleted() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, event) {
+		t.Errorf("DecodeImportCompleted() = %+v; want %+v", decoded, event)
+	}
+}
+
+func TestDecodeImportCompletedRejectsInvalidEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "Not JSON", payload: "import done"},
+		{name: "Unknown dataset", payload: `{"imports":[{"dataset":"hospitals","countries":["BRA"]}]}`},
+		{name: "Reversed dates", payload: `{"imports":[{"dataset":"covid","countries":["BRA"],"dates":{"from":"2024-02-29T00:00:00Z","to":"2024-02-01T00:00:00Z"}}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeImportCompleted([]byte(tt.payload)); err == nil {
+				t.Errorf("DecodeImportCompleted(%q) error = nil; want an error", tt.payload)
+			}
+		})
+	}
+}
+
+func TestDateRangeExtend(t *testing.T) {
+	var dates DateRange
+	for _, date := range []string{"2024-02-10", "2024-01-05", "2024-03-01", "2024-02-01"} {
+		dates.Extend(day(date))
+	}
+
+	if !dates.From.Equal(day("2024-01-05")) || !dates.To.Equal(day("2024-03-01")) {
+		t.Errorf("Extend() range = %s..%s; want 2024-01-05..2024-03-01", dates.From.Format("2006-01-02"), dates.To.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/thalesmacedo1/covid-shared
+
+go 1.23.2
+
+require github.com/neo4j/neo4j-go-driver/v5 v5.27.0
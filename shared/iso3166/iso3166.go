@@ -0,0 +1,61 @@
+// Package iso3166 is a built-in ISO 3166-1 reference table. It resolves any
+// of the alpha-2, alpha-3 or numeric forms of a country code to one canonical
+// identity, so data keyed by different code forms lands on the same country.
+package iso3166
+
+import (
+	"strings"
+)
+
+type Country struct {
+	Alpha2  string
+	Alpha3  string
+	Numeric string
+	Name    string
+}
+
+var index = buildIndex()
+
+func buildIndex() map[string]Country {
+	idx := make(map[string]Country, len(countries)*3)
+	for _, c := range countries {
+		idx[c.Alpha2] = c
+		idx[c.Alpha3] = c
+		if c.Numeric != "" {
+			idx[c.Numeric] = c
+		}
+	}
+	return idx
+}
+
+// Lookup resolves an alpha-2, alpha-3 or numeric code, ignoring case and
+// surrounding spaces. Numeric codes may omit their leading zeros.
+func Lookup(code string) (Country, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return Country{}, false
+	}
+
+	if isDigits(code) && len(code) < 3 {
+		code = strings.Repeat("0", 3-len(code)) + code
+	}
+
+	c, ok := index[code]
+	return c, ok
+}
+
+// All returns every country in the table, ordered by alpha-2 code.
+func All() []Country {
+	all := make([]Country, len(countries))
+	copy(all, countries)
+	return all
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package iso3166
+
+import (
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantFound bool
+		wantISO3  string
+	}{
+		{name: "Alpha-2", code: "BR", wantFound: true, wantISO3: "BRA"},
+		{name: "Alpha-3", code: "BRA", wantFound: true, wantISO3: "BRA"},
+		{name: "Numeric", code: "076", wantFound: true, wantISO3: "BRA"},
+		{name: "Numeric without leading zero", code: "76", wantFound: true, wantISO3: "BRA"},
+		{name: "Lower case with spaces", code: " af ", wantFound: true, wantISO3: "AFG"},
+		{name: "Kosovo as used by WHO", code: "XK", wantFound: true, wantISO3: "XKX"},
+		{name: "WHO international conveyance", code: "XXJ", wantFound: false},
+		{name: "Empty", code: "  ", wantFound: false},
+		{name: "Unknown numeric", code: "999", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			country, found := Lookup(tt.code)
+			if found != tt.wantFound {
+				t.Fatalf("Lookup(%q) found = %v; want %v", tt.code, found, tt.wantFound)
+			}
+			if found && country.Alpha3 != tt.wantISO3 {
+				t.Errorf("Lookup(%q) = %s; want %s", tt.code, country.Alpha3, tt.wantISO3)
+			}
+		})
+	}
+}
+
+func TestTableIsConsistent(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, c := range All() {
+		if len(c.Alpha2) != 2 || len(c.Alpha3) != 3 || c.Name == "" {
+			t.Errorf("malformed entry %+v", c)
+		}
+		if c.Numeric != "" && (len(c.Numeric) != 3 || !isDigits(c.Numeric)) {
+			t.Errorf("malformed numeric code in %+v", c)
+		}
+		for _, code := range []string{c.Alpha2, c.Alpha3, c.Numeric} {
+			if code == "" {
+				continue
+			}
+			if seen[code] {
+				t.Errorf("code %s appears more than once", code)
+			}
+			seen[code] = true
+		}
+	}
+}
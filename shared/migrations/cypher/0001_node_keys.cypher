@@ -0,0 +1,20 @@
+// Keys for every node the loaders MERGE on. Node keys need Enterprise
+// edition, so Community gets plain uniqueness constraints instead.
+
+// +enterprise
+CREATE CONSTRAINT country_code_key IF NOT EXISTS FOR (n:Country) REQUIRE n.code IS NODE KEY;
+// +enterprise
+CREATE CONSTRAINT region_name_key IF NOT EXISTS FOR (n:Region) REQUIRE n.name IS NODE KEY;
+// +enterprise
+CREATE CONSTRAINT date_date_key IF NOT EXISTS FOR (n:Date) REQUIRE n.date IS NODE KEY;
+// +enterprise
+CREATE CONSTRAINT vaccine_product_key IF NOT EXISTS FOR (n:Vaccine) REQUIRE n.product IS NODE KEY;
+
+// +community
+CREATE CONSTRAINT country_code_unique IF NOT EXISTS FOR (n:Country) REQUIRE n.code IS UNIQUE;
+// +community
+CREATE CONSTRAINT region_name_unique IF NOT EXISTS FOR (n:Region) REQUIRE n.name IS UNIQUE;
+// +community
+CREATE CONSTRAINT date_date_unique IF NOT EXISTS FOR (n:Date) REQUIRE n.date IS UNIQUE;
+// +community
+CREATE CONSTRAINT vaccine_product_unique IF NOT EXISTS FOR (n:Vaccine) REQUIRE n.product IS UNIQUE;
@@ -0,0 +1,11 @@
+// Stats are upserted by country and date, one node per metric family.
+
+// +enterprise
+CREATE CONSTRAINT covid_stats_key IF NOT EXISTS FOR (n:CovidStats) REQUIRE (n.countryCode, n.date) IS NODE KEY;
+// +enterprise
+CREATE CONSTRAINT vaccination_stats_key IF NOT EXISTS FOR (n:VaccinationStats) REQUIRE (n.countryCode, n.date) IS NODE KEY;
+
+// +community
+CREATE CONSTRAINT covid_stats_unique IF NOT EXISTS FOR (n:CovidStats) REQUIRE (n.countryCode, n.date) IS UNIQUE;
+// +community
+CREATE CONSTRAINT vaccination_stats_unique IF NOT EXISTS FOR (n:VaccinationStats) REQUIRE (n.countryCode, n.date) IS UNIQUE;
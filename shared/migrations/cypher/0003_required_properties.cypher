@@ -0,0 +1,9 @@
+// Property existence constraints only exist on Enterprise edition. On
+// Community the same rules are enforced by migrations.Requirements.
+
+// +enterprise
+CREATE CONSTRAINT country_name_exists IF NOT EXISTS FOR (n:Country) REQUIRE n.name IS NOT NULL;
+// +enterprise
+CREATE CONSTRAINT vaccine_company_exists IF NOT EXISTS FOR (n:Vaccine) REQUIRE n.company IS NOT NULL;
+// +enterprise
+CREATE CONSTRAINT vaccine_vaccine_exists IF NOT EXISTS FOR (n:Vaccine) REQUIRE n.vaccine IS NOT NULL;
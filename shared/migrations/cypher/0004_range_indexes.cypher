@@ -0,0 +1,5 @@
+// Range indexes for the lookups the API runs that are not covered by a key.
+
+CREATE RANGE INDEX covid_stats_date IF NOT EXISTS FOR (n:CovidStats) ON (n.date);
+CREATE RANGE INDEX vaccination_stats_date IF NOT EXISTS FOR (n:VaccinationStats) ON (n.date);
+CREATE RANGE INDEX country_name IF NOT EXISTS FOR (n:Country) ON (n.name);
@@ -0,0 +1,5 @@
+// Countries are keyed by their alpha-3 code (Country.code) and also carry the
+// alpha-2 and numeric forms, which the API accepts as lookups.
+
+CREATE CONSTRAINT country_iso2_unique IF NOT EXISTS FOR (n:Country) REQUIRE n.iso2 IS UNIQUE;
+CREATE CONSTRAINT country_numeric_unique IF NOT EXISTS FOR (n:Country) REQUIRE n.numeric IS UNIQUE;
@@ -0,0 +1,8 @@
+// Manufacturers are merged by name and linked to the vaccines they produce
+// with (:Company)-[:PRODUCES]->(:Vaccine).
+
+// +enterprise
+CREATE CONSTRAINT company_name_key IF NOT EXISTS FOR (n:Company) REQUIRE n.name IS NODE KEY;
+
+// +community
+CREATE CONSTRAINT company_name_unique IF NOT EXISTS FOR (n:Company) REQUIRE n.name IS UNIQUE;
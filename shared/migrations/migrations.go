@@ -0,0 +1,178 @@
+// Package migrations applies the numbered Cypher migrations in cypher/ to a
+// Neo4j database and records each one in a :SchemaMigration node. It is used
+// by both the ETL and the API, so either can bring a fresh database up to date.
+package migrations
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed cypher/*.cypher
+var files embed.FS
+
+type Edition string
+
+const (
+	Community  Edition = "community"
+	Enterprise Edition = "enterprise"
+)
+
+// Statement is a single Cypher schema statement. An empty Edition means the
+// statement runs on every edition.
+type Statement struct {
+	Cypher  string
+	Edition Edition
+}
+
+type Migration struct {
+	Version    int
+	Name       string
+	Checksum   string
+	Statements []Statement
+}
+
+// StatementsFor returns the statements of m that apply to edition.
+func (m Migration) StatementsFor(edition Edition) []string {
+	var statements []string
+	for _, s := range m.Statements {
+		if s.Edition == "" || s.Edition == edition {
+			statements = append(statements, s.Cypher)
+		}
+	}
+	return statements
+}
+
+// schemaName matches the kind and name of a CREATE CONSTRAINT or CREATE
+// [RANGE|TEXT|...] INDEX statement.
+var schemaName = regexp.MustCompile(`(?i)^CREATE\s+(?:\w+\s+)?(CONSTRAINT|INDEX)\s+(\w+)`)
+
+// DropStatementsFor returns the statements that drop what the statements of m
+// restricted to edition created. They let the runner replace one edition's
+// schema with the other's, so every edition-specific statement must create a
+// named constraint or index.
+func (m Migration) DropStatementsFor(edition Edition) ([]string, error) {
+	var statements []string
+	for _, s := range m.Statements {
+		if s.Edition == "" || s.Edition != edition {
+			continue
+		}
+		match := schemaName.FindStringSubmatch(s.Cypher)
+		if match == nil {
+			return nil, fmt.Errorf("migration %04d_%s: %s statement %q does not create a named constraint or index",
+				m.Version, m.Name, edition, s.Cypher)
+		}
+		statements = append(statements, fmt.Sprintf("DROP %s %s IF EXISTS", strings.ToUpper(match[1]), match[2]))
+	}
+	return statements, nil
+}
+
+// Load reads every embedded migration, ordered by version.
+func Load() ([]Migration, error) {
+	return load(files, "cypher")
+}
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".cypher" {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, err := Parse(entry.Name(), string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		if other, ok := seen[migration.Version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, entry.Name(), migration.Version)
+		}
+		seen[migration.Version] = entry.Name()
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Parse builds a migration from a file named NNNN_name.cypher. Statements end
+// with a semicolon; a "// +community" or "// +enterprise" line restricts the
+// statement that follows it to that edition. Other // lines are comments.
+func Parse(filename, content string) (Migration, error) {
+	base := strings.TrimSuffix(filename, path.Ext(filename))
+	versionStr, name, ok := strings.Cut(base, "_")
+	if !ok {
+		return Migration{}, fmt.Errorf("migration %s: file name must look like NNNN_name.cypher", filename)
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil || version <= 0 {
+		return Migration{}, fmt.Errorf("migration %s: invalid version %q", filename, versionStr)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	migration := Migration{
+		Version:  version,
+		Name:     name,
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+
+	var edition Edition
+	var current []string
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+		case line == "// +community":
+			edition = Community
+			continue
+		case line == "// +enterprise":
+			edition = Enterprise
+			continue
+		case strings.HasPrefix(line, "//"):
+			continue
+		}
+
+		current = append(current, line)
+
+		if strings.HasSuffix(line, ";") {
+			cypher := strings.TrimSuffix(strings.Join(current, "\n"), ";")
+			migration.Statements = append(migration.Statements, Statement{Cypher: cypher, Edition: edition})
+			current = nil
+			edition = ""
+		}
+	}
+
+	if len(current) > 0 {
+		return Migration{}, fmt.Errorf("migration %s: last statement is missing its terminating semicolon", filename)
+	}
+
+	return migration, nil
+}
@@ -0,0 +1,140 @@
+package migrations
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	content := `// Leading comment
+CREATE INDEX a IF NOT EXISTS FOR (n:A) ON (n.a);
+
+// +enterprise
+CREATE CONSTRAINT b IF NOT EXISTS
+FOR (n:B) REQUIRE n.b IS NODE KEY;
+// +community
+CREATE CONSTRAINT b IF NOT EXISTS FOR (n:B) REQUIRE n.b IS UNIQUE;
+`
+
+	migration, err := Parse("0007_sample.cypher", content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if migration.Version != 7 || migration.Name != "sample" {
+		t.Errorf("Parse() version, name = %d, %q; want 7, \"sample\"", migration.Version, migration.Name)
+	}
+	if len(migration.Statements) != 3 {
+		t.Fatalf("Parse() returned %d statements; want 3", len(migration.Statements))
+	}
+	if migration.Statements[1].Cypher != "CREATE CONSTRAINT b IF NOT EXISTS\nFOR (n:B) REQUIRE n.b IS NODE KEY" {
+		t.Errorf("Parse() statement 1 = %q", migration.Statements[1].Cypher)
+	}
+
+	community := migration.StatementsFor(Community)
+	if len(community) != 2 || community[1] != "CREATE CONSTRAINT b IF NOT EXISTS FOR (n:B) REQUIRE n.b IS UNIQUE" {
+		t.Errorf("StatementsFor(Community) = %q", community)
+	}
+
+	enterprise := migration.StatementsFor(Enterprise)
+	if len(enterprise) != 2 || enterprise[0] != "CREATE INDEX a IF NOT EXISTS FOR (n:A) ON (n.a)" {
+		t.Errorf("StatementsFor(Enterprise) = %q", enterprise)
+	}
+}
+
+func TestDropStatementsFor(t *testing.T) {
+	content := `CREATE INDEX a IF NOT EXISTS FOR (n:A) ON (n.a);
+// +enterprise
+CREATE CONSTRAINT b_key IF NOT EXISTS FOR (n:B) REQUIRE n.b IS NODE KEY;
+// +community
+CREATE CONSTRAINT b_unique IF NOT EXISTS FOR (n:B) REQUIRE n.b IS UNIQUE;
+// +community
+CREATE RANGE INDEX c IF NOT EXISTS FOR (n:C) ON (n.c);
+`
+	migration, err := Parse("0007_sample.cypher", content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	community, err := migration.DropStatementsFor(Community)
+	if err != nil {
+		t.Fatalf("DropStatementsFor(Community) error = %v", err)
+	}
+	if len(community) != 2 || community[0] != "DROP CONSTRAINT b_unique IF EXISTS" || community[1] != "DROP INDEX c IF EXISTS" {
+		t.Errorf("DropStatementsFor(Community) = %q", community)
+	}
+
+	enterprise, _ := migration.DropStatementsFor(Enterprise)
+	if len(enterprise) != 1 || enterprise[0] != "DROP CONSTRAINT b_key IF EXISTS" {
+		t.Errorf("DropStatementsFor(Enterprise) = %q", enterprise)
+	}
+
+	unnamed, _ := Parse("0008_unnamed.cypher", "// +community\nMATCH (n:B) SET n.b = 1;")
+	if _, err := unnamed.DropStatementsFor(Community); err == nil {
+		t.Errorf("DropStatementsFor() expected an error for a statement without a named constraint or index")
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	a, _ := Parse("0001_a.cypher", "CREATE INDEX a IF NOT EXISTS FOR (n:A) ON (n.a);")
+	b, _ := Parse("0001_a.cypher", "CREATE INDEX a IF NOT EXISTS FOR (n:A) ON (n.b);")
+
+	if a.Checksum == "" || a.Checksum == b.Checksum {
+		t.Errorf("Parse() checksums %q and %q should differ", a.Checksum, b.Checksum)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{name: "Missing version", filename: "sample.cypher", content: "RETURN 1;"},
+		{name: "Invalid version", filename: "abc_sample.cypher", content: "RETURN 1;"},
+		{name: "Unterminated statement", filename: "0001_sample.cypher", content: "RETURN 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.filename, tt.content); err == nil {
+				t.Errorf("Parse(%q) expected an error", tt.filename)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %d has version %d; versions must be contiguous", i, migration.Version)
+		}
+		if len(migration.StatementsFor(Community)) == 0 && len(migration.StatementsFor(Enterprise)) == 0 {
+			t.Errorf("migration %04d_%s has no statements", migration.Version, migration.Name)
+		}
+		for _, edition := range []Edition{Community, Enterprise} {
+			if _, err := migration.DropStatementsFor(edition); err != nil {
+				t.Errorf("migration %04d_%s cannot switch editions: %v", migration.Version, migration.Name, err)
+			}
+		}
+	}
+}
+
+func TestMissingProperties(t *testing.T) {
+	if missing := MissingProperties("Country", map[string]interface{}{"name": "Brazil"}); len(missing) != 0 {
+		t.Errorf("MissingProperties() = %v; want none", missing)
+	}
+
+	missing := MissingProperties("Vaccine", map[string]interface{}{"company": " ", "product": "AZD1222"})
+	if len(missing) != 2 || missing[0] != "company" || missing[1] != "vaccine" {
+		t.Errorf("MissingProperties() = %v; want [company vaccine]", missing)
+	}
+
+	if missing := MissingProperties("Region", map[string]interface{}{}); len(missing) != 0 {
+		t.Errorf("MissingProperties() = %v; want none for a label without requirements", missing)
+	}
+}
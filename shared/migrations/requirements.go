@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Requirement lists the properties every node with Label must carry. On
+// Enterprise edition migration 0003 enforces them in the database; on
+// Community they are only enforced by MissingProperties and CheckExistence.
+type Requirement struct {
+	Label      string
+	Properties []string
+}
+
+var Requirements = []Requirement{
+	{Label: "Country", Properties: []string{"name"}},
+	{Label: "Vaccine", Properties: []string{"company", "vaccine"}},
+}
+
+// MissingProperties returns the required properties of label that are absent
+// or blank in props. Loaders call it before writing a node.
+func MissingProperties(label string, props map[string]interface{}) []string {
+	var missing []string
+	for _, requirement := range Requirements {
+		if requirement.Label != label {
+			continue
+		}
+		for _, property := range requirement.Properties {
+			value, ok := props[property]
+			if !ok || value == nil {
+				missing = append(missing, property)
+				continue
+			}
+			if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+				missing = append(missing, property)
+			}
+		}
+	}
+	return missing
+}
+
+// Violation counts the nodes of Label that lack Property.
+type Violation struct {
+	Label    string
+	Property string
+	Count    int
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%d :%s nodes without %s", v.Count, v.Label, v.Property)
+}
+
+// CheckExistence looks for nodes that break a Requirement. It is the
+// Community edition stand-in for property existence constraints.
+func (r *Runner) CheckExistence(ctx context.Context) ([]Violation, error) {
+	var violations []Violation
+
+	for _, requirement := range Requirements {
+		for _, property := range requirement.Properties {
+			// Labels and property names come from Requirements, never from input
+			query := fmt.Sprintf(`MATCH (n:%s) WHERE n.%s IS NULL RETURN count(n) AS missing`, requirement.Label, property)
+
+			result, err := neo4j.ExecuteQuery(ctx, r.driver, query, nil,
+				neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithReadersRouting())
+			if err != nil {
+				return nil, fmt.Errorf("failed to check %s.%s: %w", requirement.Label, property, err)
+			}
+
+			missing, _, err := neo4j.GetRecordValue[int64](result.Records[0], "missing")
+			if err != nil {
+				return nil, err
+			}
+			if missing > 0 {
+				violations = append(violations, Violation{
+					Label:    requirement.Label,
+					Property: property,
+					Count:    int(missing),
+				})
+			}
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,172 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type Runner struct {
+	driver     neo4j.DriverWithContext
+	migrations []Migration
+}
+
+// Result describes what a call to Runner.Run did.
+type Result struct {
+	Edition Edition
+	Applied []Migration
+}
+
+func NewRunner(driver neo4j.DriverWithContext) (*Runner, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		driver:     driver,
+		migrations: migrations,
+	}, nil
+}
+
+// Edition reports whether the server is a Community or Enterprise install.
+func (r *Runner) Edition(ctx context.Context) (Edition, error) {
+	result, err := neo4j.ExecuteQuery(ctx, r.driver,
+		`CALL dbms.components() YIELD edition RETURN edition`,
+		nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect Neo4j edition: %w", err)
+	}
+
+	if len(result.Records) == 0 {
+		return "", fmt.Errorf("failed to detect Neo4j edition: no components reported")
+	}
+
+	edition, _, err := neo4j.GetRecordValue[string](result.Records[0], "edition")
+	if err != nil {
+		return "", fmt.Errorf("failed to detect Neo4j edition: %w", err)
+	}
+
+	if strings.EqualFold(edition, string(Enterprise)) {
+		return Enterprise, nil
+	}
+	return Community, nil
+}
+
+// Run applies every migration that has not been applied yet, in version
+// order. It refuses to continue if an applied migration has been edited. A
+// migration applied while the server ran another edition is applied again,
+// after dropping what that edition's statements created, so that an upgrade
+// from Community picks up the Enterprise-only constraints.
+func (r *Runner) Run(ctx context.Context) (*Result, error) {
+	edition, err := r.Edition(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.exec(ctx, `CREATE CONSTRAINT schema_migration_version IF NOT EXISTS FOR (m:SchemaMigration) REQUIRE m.version IS UNIQUE`); err != nil {
+		return nil, fmt.Errorf("failed to prepare schema migrations: %w", err)
+	}
+
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Edition: edition}
+
+	for _, migration := range r.migrations {
+		if previous, ok := applied[migration.Version]; ok {
+			if previous.checksum != migration.Checksum {
+				return nil, fmt.Errorf("migration %04d_%s was changed after it was applied (checksum %s, expected %s)",
+					migration.Version, migration.Name, migration.Checksum, previous.checksum)
+			}
+			if previous.edition == edition {
+				continue
+			}
+
+			drops, err := migration.DropStatementsFor(previous.edition)
+			if err != nil {
+				return nil, err
+			}
+			for _, statement := range drops {
+				if err := r.exec(ctx, statement); err != nil {
+					return nil, fmt.Errorf("migration %04d_%s failed to drop the %s schema: %w", migration.Version, migration.Name, previous.edition, err)
+				}
+			}
+		}
+
+		for _, statement := range migration.StatementsFor(edition) {
+			if err := r.exec(ctx, statement); err != nil {
+				return nil, fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		if err := r.record(ctx, migration, edition); err != nil {
+			return nil, err
+		}
+		result.Applied = append(result.Applied, migration)
+	}
+
+	return result, nil
+}
+
+// exec runs a schema statement in its own auto-commit transaction, since
+// schema changes cannot share a transaction with data writes.
+func (r *Runner) exec(ctx context.Context, statement string) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, statement, nil)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
+
+// appliedMigration is what a :SchemaMigration node records about a migration.
+type appliedMigration struct {
+	checksum string
+	edition  Edition
+}
+
+func (r *Runner) appliedMigrations(ctx context.Context) (map[int]appliedMigration, error) {
+	result, err := neo4j.ExecuteQuery(ctx, r.driver,
+		`MATCH (m:SchemaMigration) RETURN m.version AS version, m.checksum AS checksum, m.edition AS edition`,
+		nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]appliedMigration, len(result.Records))
+	for _, record := range result.Records {
+		version, _, err := neo4j.GetRecordValue[int64](record, "version")
+		if err != nil {
+			return nil, err
+		}
+		checksum, _, _ := neo4j.GetRecordValue[string](record, "checksum")
+		edition, _, _ := neo4j.GetRecordValue[string](record, "edition")
+		applied[int(version)] = appliedMigration{checksum: checksum, edition: Edition(edition)}
+	}
+
+	return applied, nil
+}
+
+func (r *Runner) record(ctx context.Context, migration Migration, edition Edition) error {
+	_, err := neo4j.ExecuteQuery(ctx, r.driver,
+		`MERGE (m:SchemaMigration {version: $version})
+         SET m.name = $name, m.checksum = $checksum, m.edition = $edition, m.appliedAt = datetime()`,
+		map[string]interface{}{
+			"version":  migration.Version,
+			"name":     migration.Name,
+			"checksum": migration.Checksum,
+			"edition":  string(edition),
+		}, neo4j.EagerResultTransformer)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}